@@ -15,6 +15,12 @@ type Config struct {
 	CodeExpiryMinutes    int
 	RateLimitPerHour     int
 	AllowedCallbackHosts string
+	LogLevel             string
+	LogFormat            string
+	RedisURL             string
+	WebhookSigningSecret string
+	GRPCPort             string
+	TrustedProxies       string
 }
 
 func Load() *Config {
@@ -29,6 +35,12 @@ func Load() *Config {
 		CodeExpiryMinutes:    getEnvInt("CODE_EXPIRY_MINUTES", 5),
 		RateLimitPerHour:     getEnvInt("RATE_LIMIT_PER_HOUR", 3),
 		AllowedCallbackHosts: getEnv("ALLOWED_CALLBACK_HOSTS", "localhost,127.0.0.1,::1"),
+		LogLevel:             getEnv("LOG_LEVEL", "info"),
+		LogFormat:            getEnv("LOG_FORMAT", "json"),
+		RedisURL:             getEnv("REDIS_URL", ""),
+		WebhookSigningSecret: getEnv("WEBHOOK_SIGNING_SECRET", ""),
+		GRPCPort:             getEnv("GRPC_PORT", ""),
+		TrustedProxies:       getEnv("TRUSTED_PROXIES", ""),
 	}
 }
 
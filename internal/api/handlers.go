@@ -2,6 +2,7 @@ package api
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/lonanche/geo-verification/internal/verification"
@@ -18,8 +19,9 @@ func NewHandler(verificationService *verification.Service) *Handler {
 }
 
 type StartVerificationRequest struct {
-	UserID      string `json:"user_id" binding:"required"`
-	CallbackURL string `json:"callback_url,omitempty"`
+	UserID        string `json:"user_id" binding:"required"`
+	CallbackURL   string `json:"callback_url,omitempty"`
+	WebhookSecret string `json:"webhook_secret,omitempty"`
 }
 
 type StartVerificationResponse struct {
@@ -45,12 +47,12 @@ func (h *Handler) StartVerification(c *gin.Context) {
 		return
 	}
 
-	session, err := h.verificationService.StartVerification(req.UserID, req.CallbackURL)
+	session, err := h.verificationService.StartVerification(req.UserID, req.CallbackURL, req.WebhookSecret, ClientIP(c))
 	if err != nil {
 		statusCode := http.StatusInternalServerError
 		errorType := "internal_error"
 
-		if err.Error() == "rate limit exceeded for user "+req.UserID {
+		if err.Error() == "rate limit exceeded for user "+req.UserID || strings.HasPrefix(err.Error(), "rate limit exceeded for client IP") {
 			statusCode = http.StatusTooManyRequests
 			errorType = "rate_limit_exceeded"
 		} else if err.Error() == "user must add the bot account as a friend first before verification can proceed" {
@@ -105,6 +107,31 @@ func (h *Handler) GetVerificationStatus(c *gin.Context) {
 }
 
 
+func (h *Handler) GetWebhookDeliveries(c *gin.Context) {
+	sessionID := c.Param("session_id")
+	if sessionID == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_request",
+			Message: "session_id is required",
+		})
+		return
+	}
+
+	deliveries, err := h.verificationService.GetDeliveries(sessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"session_id": sessionID,
+		"deliveries": deliveries,
+	})
+}
+
 func (h *Handler) HealthCheck(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"status":    "healthy",
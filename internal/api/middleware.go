@@ -0,0 +1,133 @@
+package api
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// clientIPContextKey is the gin.Context key ClientIPMiddleware stores the
+// resolved client IP under.
+const clientIPContextKey = "client_ip"
+
+// ParseTrustedProxies parses a comma-separated CIDR list (TRUSTED_PROXIES)
+// into the form ClientIPMiddleware expects. Entries that fail to parse are
+// skipped so a typo doesn't take down the whole service.
+func ParseTrustedProxies(trustedProxies string) []*net.IPNet {
+	var nets []*net.IPNet
+	if trustedProxies == "" {
+		return nets
+	}
+	for _, entry := range strings.Split(trustedProxies, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			// Bare IP, e.g. a single load balancer address.
+			if ip := net.ParseIP(entry); ip != nil {
+				bits := 32
+				if ip.To4() == nil {
+					bits = 128
+				}
+				entry = ip.String() + "/" + strconv.Itoa(bits)
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			continue
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets
+}
+
+// ClientIPMiddleware resolves the real client IP and stores it in the gin
+// context for handlers to read via ClientIP. Without this, a service sitting
+// behind a reverse proxy sees every request as coming from the proxy's
+// address, which defeats per-IP rate limiting.
+//
+// When RemoteAddr is not in trustedProxies, RemoteAddr is used and both
+// headers are ignored. When it is, an X-Real-IP header from that proxy is
+// trusted outright; without one, X-Forwarded-For is walked from right to
+// left, skipping trusted CIDRs, and the first untrusted address is used.
+// RemoteAddr is the fallback when a trusted proxy sends neither header, and
+// the leftmost X-Forwarded-For entry when every hop in it is trusted.
+func ClientIPMiddleware(trustedProxies []*net.IPNet) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(clientIPContextKey, resolveClientIP(c, trustedProxies))
+		c.Next()
+	}
+}
+
+// ClientIP returns the IP ClientIPMiddleware resolved for this request, or
+// c.ClientIP() (gin's own best-effort guess) if the middleware wasn't run.
+func ClientIP(c *gin.Context) string {
+	if ip, exists := c.Get(clientIPContextKey); exists {
+		if s, ok := ip.(string); ok {
+			return s
+		}
+	}
+	return c.ClientIP()
+}
+
+func resolveClientIP(c *gin.Context, trustedProxies []*net.IPNet) string {
+	remoteIP := stripPort(c.Request.RemoteAddr)
+	if !isTrusted(remoteIP, trustedProxies) {
+		return remoteIP
+	}
+
+	if realIP := strings.TrimSpace(c.GetHeader("X-Real-IP")); realIP != "" {
+		return realIP
+	}
+
+	forwardedFor := c.GetHeader("X-Forwarded-For")
+	if forwardedFor == "" {
+		return remoteIP
+	}
+
+	hops := strings.Split(forwardedFor, ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		if !isTrusted(hop, trustedProxies) {
+			return hop
+		}
+	}
+
+	// Every hop was trusted; fall back to the leftmost (original client).
+	return strings.TrimSpace(hops[0])
+}
+
+func isTrusted(ip string, trustedProxies []*net.IPNet) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, ipNet := range trustedProxies {
+		if ipNet.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+func stripPort(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
+
+// SetupMiddleware registers the service's global gin middleware: request
+// logging/recovery defaults plus client-IP resolution for rate limiting.
+func SetupMiddleware(router *gin.Engine, trustedProxies []*net.IPNet) {
+	router.Use(gin.Logger())
+	router.Use(gin.Recovery())
+	router.Use(ClientIPMiddleware(trustedProxies))
+}
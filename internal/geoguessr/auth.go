@@ -0,0 +1,106 @@
+package geoguessr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// LoginWithPassword signs in with an email/password instead of a
+// pre-extracted NCFA token. On success, the _ncfa cookie Geoguessr sets in
+// response is captured by the client's cookie jar and mirrored onto
+// c.ncfaToken, since the rest of HTTPClient authenticates by setting the
+// Cookie header directly rather than relying on the jar per-request.
+func (c *HTTPClient) LoginWithPassword(email, password string) error {
+	c.logger.Infof("Logging in with email/password")
+
+	url := fmt.Sprintf("%s/accounts/signin", c.baseURL)
+	payload, err := json.Marshal(map[string]string{"email": email, "password": password})
+	if err != nil {
+		return fmt.Errorf("failed to marshal login payload: %w", err)
+	}
+
+	resp, body, err := c.doWithRetry(func() (*http.Request, error) {
+		req, err := http.NewRequest("POST", url, bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "*/*")
+		req.Header.Set("Origin", "https://www.geoguessr.com")
+		req.Header.Set("Referer", "https://www.geoguessr.com/")
+		req.Header.Set("x-client", "web")
+		return req, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sign in: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to sign in with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	ncfa, err := c.ncfaFromJar()
+	if err != nil {
+		return fmt.Errorf("signed in but no _ncfa cookie was set: %w", err)
+	}
+	c.ncfaToken = ncfa
+
+	c.logger.Infof("Successfully logged in")
+	return nil
+}
+
+// SaveSession writes the client's current cookie jar (including the _ncfa
+// session cookie) to w as JSON, for LoadSession to restore later.
+func (c *HTTPClient) SaveSession(w io.Writer) error {
+	jarURL, err := url.Parse(c.baseURL)
+	if err != nil {
+		return fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	if err := json.NewEncoder(w).Encode(c.httpClient.Jar.Cookies(jarURL)); err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+	return nil
+}
+
+// LoadSession restores a cookie jar previously written by SaveSession and
+// mirrors its _ncfa cookie onto c.ncfaToken, so the client can resume
+// without calling LoginWithPassword again.
+func (c *HTTPClient) LoadSession(r io.Reader) error {
+	var cookies []*http.Cookie
+	if err := json.NewDecoder(r).Decode(&cookies); err != nil {
+		return fmt.Errorf("failed to decode session: %w", err)
+	}
+
+	jarURL, err := url.Parse(c.baseURL)
+	if err != nil {
+		return fmt.Errorf("invalid base URL: %w", err)
+	}
+	c.httpClient.Jar.SetCookies(jarURL, cookies)
+
+	ncfa, err := c.ncfaFromJar()
+	if err != nil {
+		return fmt.Errorf("loaded session has no _ncfa cookie: %w", err)
+	}
+	c.ncfaToken = ncfa
+
+	return nil
+}
+
+func (c *HTTPClient) ncfaFromJar() (string, error) {
+	jarURL, err := url.Parse(c.baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	for _, cookie := range c.httpClient.Jar.Cookies(jarURL) {
+		if cookie.Name == "_ncfa" {
+			return cookie.Value, nil
+		}
+	}
+	return "", fmt.Errorf("_ncfa cookie not found")
+}
@@ -5,112 +5,131 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
+	"regexp"
+	"sync"
 	"time"
+
+	"go.uber.org/zap"
 )
 
 type Client interface {
 	Login() error
+	LoginWithPassword(email, password string) error
+	SaveSession(w io.Writer) error
+	LoadSession(r io.Reader) error
 	IsFriend(userID string) (bool, error)
+	GetFriends() ([]Friend, error)
 	GetPendingFriendRequests() ([]string, error)
 	AcceptFriendRequest(userID string) error
 	ReadChatMessages(userID string) ([]ChatMessage, error)
+	SendChatMessage(userID, text string) error
+	VerifyUser(userID string, challenge Challenge) (VerificationResult, error)
 	IsLoggedIn() bool
 }
 
 type HTTPClient struct {
-	httpClient *http.Client
-	baseURL    string
-	ncfaToken  string
+	httpClient  *http.Client
+	baseURL     string
+	chatBaseURL string
+	ncfaToken   string
+	logger      Logger
+	retryPolicy RetryPolicy
+
+	// chatMarkMu guards chatMark, the per-user high-water mark PollChat uses
+	// to avoid replaying messages it has already delivered to a handler.
+	chatMarkMu sync.Mutex
+	chatMark   map[string]time.Time
 }
 
-func NewClient(ncfaToken string) *HTTPClient {
-	return &HTTPClient{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		baseURL:   "https://www.geoguessr.com/api/v3",
-		ncfaToken: ncfaToken,
-	}
+// NewClient builds an HTTPClient with the default retry policy and base
+// URL. Use NewClientWithOptions to override either.
+func NewClient(ncfaToken string, logger *zap.SugaredLogger) *HTTPClient {
+	return NewClientWithOptions(ncfaToken, WithLogger(logger))
 }
 
 func (c *HTTPClient) Login() error {
 	// No login needed when using NCFA token
-	log.Printf("Using NCFA token authentication")
+	c.logger.Infof("Using NCFA token authentication")
 	return nil
 }
 
 func (c *HTTPClient) IsFriend(userID string) (bool, error) {
-	log.Printf("Checking if user %s is a friend", userID)
+	c.logger.Infof("Checking if user %s is a friend", userID)
 
-	// Get friends list
-	url := fmt.Sprintf("%s/social/friends", c.baseURL)
-	req, err := http.NewRequest("GET", url, nil)
+	friends, err := c.GetFriends()
 	if err != nil {
-		return false, fmt.Errorf("failed to create request: %w", err)
+		return false, err
+	}
+
+	// Check if userID is in the friends list
+	for _, friend := range friends {
+		if friend.UserID == userID {
+			return true, nil
+		}
 	}
 
-	// Set headers
-	req.Header.Set("Accept", "*/*")
-	req.Header.Set("Origin", "https://www.geoguessr.com")
-	req.Header.Set("Referer", "https://www.geoguessr.com/")
-	req.Header.Set("x-client", "web")
-	req.Header.Set("Cookie", fmt.Sprintf("_ncfa=%s", c.ncfaToken))
+	return false, nil
+}
 
-	resp, err := c.httpClient.Do(req)
+// GetFriends returns the caller's current friends list. Unlike
+// GetPendingFriendRequests, these are conversations that are already
+// established (the friend request was already accepted on one side or the
+// other), so it's the source PollAllConversations needs to keep polling a
+// user's chat after their friend request drops off the pending list.
+func (c *HTTPClient) GetFriends() ([]Friend, error) {
+	url := fmt.Sprintf("%s/social/friends", c.baseURL)
+	resp, body, err := c.doWithRetry(func() (*http.Request, error) {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "*/*")
+		req.Header.Set("Origin", "https://www.geoguessr.com")
+		req.Header.Set("Referer", "https://www.geoguessr.com/")
+		req.Header.Set("x-client", "web")
+		req.Header.Set("Cookie", fmt.Sprintf("_ncfa=%s", c.ncfaToken))
+		return req, nil
+	})
 	if err != nil {
-		return false, fmt.Errorf("failed to get friends list: %w", err)
+		return nil, fmt.Errorf("failed to get friends list: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, _ := io.ReadAll(resp.Body)
-	log.Printf("Friends list response: %d - %s", resp.StatusCode, string(body))
+	c.logger.Infof("Friends list response: %d - %s", resp.StatusCode, string(body))
 
 	if resp.StatusCode != http.StatusOK {
-		return false, fmt.Errorf("failed to get friends list with status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("failed to get friends list with status %d: %s", resp.StatusCode, string(body))
 	}
 
-	// Parse JSON response properly
 	var friends []Friend
 	if err := json.Unmarshal(body, &friends); err != nil {
-		return false, fmt.Errorf("failed to parse friends list: %w", err)
+		return nil, fmt.Errorf("failed to parse friends list: %w", err)
 	}
 
-	// Check if userID is in the friends list
-	for _, friend := range friends {
-		if friend.UserID == userID {
-			return true, nil
-		}
-	}
-
-	return false, nil
+	return friends, nil
 }
 
 func (c *HTTPClient) GetPendingFriendRequests() ([]string, error) {
-	log.Printf("Getting pending friend requests")
+	c.logger.Infof("Getting pending friend requests")
 
 	url := fmt.Sprintf("%s/social/friends/received", c.baseURL)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("Accept", "*/*")
-	req.Header.Set("Origin", "https://www.geoguessr.com")
-	req.Header.Set("Referer", "https://www.geoguessr.com/")
-	req.Header.Set("x-client", "web")
-	req.Header.Set("Cookie", fmt.Sprintf("_ncfa=%s", c.ncfaToken))
-
-	resp, err := c.httpClient.Do(req)
+	resp, body, err := c.doWithRetry(func() (*http.Request, error) {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "*/*")
+		req.Header.Set("Origin", "https://www.geoguessr.com")
+		req.Header.Set("Referer", "https://www.geoguessr.com/")
+		req.Header.Set("x-client", "web")
+		req.Header.Set("Cookie", fmt.Sprintf("_ncfa=%s", c.ncfaToken))
+		return req, nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pending friend requests: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, _ := io.ReadAll(resp.Body)
-	log.Printf("Pending friend requests response: %d - %s", resp.StatusCode, string(body))
+	c.logger.Infof("Pending friend requests response: %d - %s", resp.StatusCode, string(body))
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("failed to get pending friend requests with status %d: %s", resp.StatusCode, string(body))
@@ -127,72 +146,66 @@ func (c *HTTPClient) GetPendingFriendRequests() ([]string, error) {
 		userIDs = append(userIDs, request.UserID)
 	}
 
-	log.Printf("Found %d pending friend requests: %v", len(userIDs), userIDs)
+	c.logger.Infof("Found %d pending friend requests: %v", len(userIDs), userIDs)
 	return userIDs, nil
 }
 
 func (c *HTTPClient) AcceptFriendRequest(userID string) error {
-	log.Printf("Accepting friend request from user: %s", userID)
+	c.logger.Infof("Accepting friend request from user: %s", userID)
 
 	// Correct endpoint for accepting friend requests
 	url := fmt.Sprintf("%s/social/friends/%s?context=", c.baseURL, userID)
 
-	req, err := http.NewRequest("PUT", url, bytes.NewBuffer([]byte("{}")))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "*/*")
-	req.Header.Set("Origin", "https://www.geoguessr.com")
-	req.Header.Set("Referer", "https://www.geoguessr.com/")
-	req.Header.Set("x-client", "web")
-	req.Header.Set("Cookie", fmt.Sprintf("_ncfa=%s", c.ncfaToken))
-
-	resp, err := c.httpClient.Do(req)
+	resp, body, err := c.doWithRetry(func() (*http.Request, error) {
+		req, err := http.NewRequest("PUT", url, bytes.NewBuffer([]byte("{}")))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "*/*")
+		req.Header.Set("Origin", "https://www.geoguessr.com")
+		req.Header.Set("Referer", "https://www.geoguessr.com/")
+		req.Header.Set("x-client", "web")
+		req.Header.Set("Cookie", fmt.Sprintf("_ncfa=%s", c.ncfaToken))
+		return req, nil
+	})
 	if err != nil {
 		return fmt.Errorf("failed to accept friend request: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, _ := io.ReadAll(resp.Body)
-	log.Printf("Accept friend request response: %d - %s", resp.StatusCode, string(body))
+	c.logger.Infof("Accept friend request response: %d - %s", resp.StatusCode, string(body))
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
 		return fmt.Errorf("failed to accept friend request with status %d: %s", resp.StatusCode, string(body))
 	}
 
-	log.Printf("Successfully accepted friend request from %s", userID)
+	c.logger.Infof("Successfully accepted friend request from %s", userID)
 	return nil
 }
 
 func (c *HTTPClient) ReadChatMessages(userID string) ([]ChatMessage, error) {
-	log.Printf("Reading chat messages from user: %s", userID)
+	c.logger.Infof("Reading chat messages from user: %s", userID)
 
 	// Use v4 chat API
-	url := fmt.Sprintf("https://www.geoguessr.com/api/v4/chat/%s", userID)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("Accept", "*/*")
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Origin", "https://www.geoguessr.com")
-	req.Header.Set("Referer", "https://www.geoguessr.com/")
-	req.Header.Set("x-client", "web")
-	req.Header.Set("Cookie", fmt.Sprintf("_ncfa=%s", c.ncfaToken))
-
-	resp, err := c.httpClient.Do(req)
+	url := fmt.Sprintf("%s/chat/%s", c.chatBaseURL, userID)
+	resp, body, err := c.doWithRetry(func() (*http.Request, error) {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "*/*")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Origin", "https://www.geoguessr.com")
+		req.Header.Set("Referer", "https://www.geoguessr.com/")
+		req.Header.Set("x-client", "web")
+		req.Header.Set("Cookie", fmt.Sprintf("_ncfa=%s", c.ncfaToken))
+		return req, nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to read chat messages: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, _ := io.ReadAll(resp.Body)
-	log.Printf("Chat messages response: %d - %s", resp.StatusCode, string(body))
+	c.logger.Infof("Chat messages response: %d - %s", resp.StatusCode, string(body))
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("failed to read chat messages with status %d: %s", resp.StatusCode, string(body))
@@ -207,6 +220,87 @@ func (c *HTTPClient) ReadChatMessages(userID string) ([]ChatMessage, error) {
 	return chatResponse.Messages, nil
 }
 
+func (c *HTTPClient) SendChatMessage(userID, text string) error {
+	c.logger.Infof("Sending chat message to user: %s", userID)
+
+	url := fmt.Sprintf("%s/chat/%s", c.chatBaseURL, userID)
+	payload, err := json.Marshal(map[string]string{"textPayload": text})
+	if err != nil {
+		return fmt.Errorf("failed to marshal chat message: %w", err)
+	}
+
+	resp, body, err := c.doWithRetry(func() (*http.Request, error) {
+		req, err := http.NewRequest("POST", url, bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "*/*")
+		req.Header.Set("Origin", "https://www.geoguessr.com")
+		req.Header.Set("Referer", "https://www.geoguessr.com/")
+		req.Header.Set("x-client", "web")
+		req.Header.Set("Cookie", fmt.Sprintf("_ncfa=%s", c.ncfaToken))
+		return req, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send chat message: %w", err)
+	}
+
+	c.logger.Infof("Send chat message response: %d - %s", resp.StatusCode, string(body))
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to send chat message with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// VerifyUser sends challenge.Prompt to userID and polls ReadChatMessages
+// for a reply whose TextPayload matches challenge.ExpectedPattern, up to
+// challenge.Timeout. It builds on SendChatMessage and ReadChatMessages
+// rather than a bespoke endpoint, so it works the same whether the reply
+// arrives immediately or after several poll intervals.
+func (c *HTTPClient) VerifyUser(userID string, challenge Challenge) (VerificationResult, error) {
+	pollInterval := challenge.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+	timeout := challenge.Timeout
+	if timeout <= 0 {
+		timeout = 2 * time.Minute
+	}
+
+	pattern, err := regexp.Compile(challenge.ExpectedPattern)
+	if err != nil {
+		return VerificationResult{}, fmt.Errorf("invalid expected pattern: %w", err)
+	}
+
+	if challenge.Prompt != "" {
+		if err := c.SendChatMessage(userID, challenge.Prompt); err != nil {
+			return VerificationResult{}, fmt.Errorf("failed to send verification prompt: %w", err)
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		messages, err := c.ReadChatMessages(userID)
+		if err != nil {
+			return VerificationResult{}, fmt.Errorf("failed to read chat messages: %w", err)
+		}
+
+		for _, message := range messages {
+			if message.SourceID == userID && pattern.MatchString(message.TextPayload) {
+				return VerificationResult{Verified: true, MatchedMessage: message.TextPayload}, nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return VerificationResult{Verified: false}, nil
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
 func (c *HTTPClient) IsLoggedIn() bool {
 	// Always logged in with NCFA token
 	return c.ncfaToken != ""
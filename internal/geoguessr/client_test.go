@@ -0,0 +1,130 @@
+package geoguessr
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSendChatMessage(t *testing.T) {
+	var gotMethod, gotPath string
+	var gotPayload map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&gotPayload)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions("test-ncfa", WithChatBaseURL(server.URL))
+
+	if err := client.SendChatMessage("user-1", "hello"); err != nil {
+		t.Fatalf("SendChatMessage returned error: %v", err)
+	}
+
+	if gotMethod != http.MethodPost {
+		t.Errorf("method = %q, want POST", gotMethod)
+	}
+	if gotPath != "/chat/user-1" {
+		t.Errorf("path = %q, want /chat/user-1", gotPath)
+	}
+	if gotPayload["textPayload"] != "hello" {
+		t.Errorf("textPayload = %q, want %q", gotPayload["textPayload"], "hello")
+	}
+}
+
+func TestSendChatMessageErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions("test-ncfa", WithChatBaseURL(server.URL), WithRetryPolicy(RetryPolicy{
+		InitialInterval: time.Millisecond,
+		Multiplier:      1,
+		MaxInterval:     time.Millisecond,
+		MaxElapsedTime:  0,
+	}))
+
+	if err := client.SendChatMessage("user-1", "hello"); err == nil {
+		t.Fatal("expected an error for a non-2xx response, got nil")
+	}
+}
+
+func TestVerifyUser(t *testing.T) {
+	var messagesSent int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			messagesSent++
+			w.WriteHeader(http.StatusOK)
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(ChatResponse{
+				Messages: []ChatMessage{
+					{SourceID: "user-1", TextPayload: "here's my code: 123456", SentAt: time.Now().Format(time.RFC3339)},
+				},
+			})
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions("test-ncfa", WithChatBaseURL(server.URL))
+
+	result, err := client.VerifyUser("user-1", Challenge{
+		Prompt:          "send me the code",
+		ExpectedPattern: `\d{6}`,
+		PollInterval:    10 * time.Millisecond,
+		Timeout:         time.Second,
+	})
+	if err != nil {
+		t.Fatalf("VerifyUser returned error: %v", err)
+	}
+	if !result.Verified {
+		t.Fatalf("result.Verified = false, want true (matched message %q)", result.MatchedMessage)
+	}
+	if result.MatchedMessage != "here's my code: 123456" {
+		t.Errorf("MatchedMessage = %q, want the matching reply", result.MatchedMessage)
+	}
+	if messagesSent != 1 {
+		t.Errorf("messages sent = %d, want 1 prompt", messagesSent)
+	}
+}
+
+func TestVerifyUserTimesOutWithoutMatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusOK)
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(ChatResponse{
+				Messages: []ChatMessage{
+					{SourceID: "user-1", TextPayload: "nope", SentAt: time.Now().Format(time.RFC3339)},
+				},
+			})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions("test-ncfa", WithChatBaseURL(server.URL))
+
+	result, err := client.VerifyUser("user-1", Challenge{
+		ExpectedPattern: `\d{6}`,
+		PollInterval:    10 * time.Millisecond,
+		Timeout:         30 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("VerifyUser returned error: %v", err)
+	}
+	if result.Verified {
+		t.Fatal("result.Verified = true, want false since no reply matched")
+	}
+}
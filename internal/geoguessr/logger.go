@@ -0,0 +1,65 @@
+package geoguessr
+
+import (
+	"fmt"
+	"regexp"
+
+	"go.uber.org/zap"
+)
+
+// Logger is the logging interface HTTPClient depends on, shaped to match
+// zap.SugaredLogger's printf-style methods so a *zap.SugaredLogger can be
+// passed in directly via WithLogger. WithLogger accepts only a
+// *zap.SugaredLogger; other Logger implementations can be wrapped with
+// NewRedactingLogger but are not installed on an HTTPClient through it.
+type Logger interface {
+	Debugf(template string, args ...interface{})
+	Infof(template string, args ...interface{})
+	Warnf(template string, args ...interface{})
+	Errorf(template string, args ...interface{})
+}
+
+var _ Logger = (*zap.SugaredLogger)(nil)
+
+const maxLoggedMessageLength = 500
+
+var ncfaCookiePattern = regexp.MustCompile(`_ncfa=[^;\s"]+`)
+
+// redactingLogger wraps a Logger, scrubbing the NCFA session cookie and
+// truncating long messages before they reach the underlying logger.
+// HTTPClient's log lines include raw API response bodies, which can carry
+// auth material or chat message content that shouldn't end up verbatim in a
+// log aggregator.
+type redactingLogger struct {
+	underlying Logger
+}
+
+// NewRedactingLogger wraps underlying with NCFA token and message
+// truncation redaction. NewClientWithOptions uses this by default.
+func NewRedactingLogger(underlying Logger) Logger {
+	return &redactingLogger{underlying: underlying}
+}
+
+func (l *redactingLogger) Debugf(template string, args ...interface{}) {
+	l.underlying.Debugf("%s", redact(fmt.Sprintf(template, args...)))
+}
+
+func (l *redactingLogger) Infof(template string, args ...interface{}) {
+	l.underlying.Infof("%s", redact(fmt.Sprintf(template, args...)))
+}
+
+func (l *redactingLogger) Warnf(template string, args ...interface{}) {
+	l.underlying.Warnf("%s", redact(fmt.Sprintf(template, args...)))
+}
+
+func (l *redactingLogger) Errorf(template string, args ...interface{}) {
+	l.underlying.Errorf("%s", redact(fmt.Sprintf(template, args...)))
+}
+
+func redact(message string) string {
+	message = ncfaCookiePattern.ReplaceAllString(message, "_ncfa=[REDACTED]")
+	if len(message) > maxLoggedMessageLength {
+		message = message[:maxLoggedMessageLength] + "...[truncated]"
+	}
+	return message
+}
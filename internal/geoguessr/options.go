@@ -0,0 +1,92 @@
+package geoguessr
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Option configures an HTTPClient built via NewClientWithOptions.
+type Option func(*HTTPClient)
+
+// WithRetryPolicy overrides the default exponential-backoff retry policy
+// used for every Geoguessr API call.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *HTTPClient) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithHTTPClient overrides the underlying *http.Client, e.g. to set a
+// custom transport or timeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *HTTPClient) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBaseURL overrides the v3 API base URL, e.g. to point at a mock server
+// in tests.
+func WithBaseURL(baseURL string) Option {
+	return func(c *HTTPClient) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithChatBaseURL overrides the v4 chat API base URL, e.g. to point at a
+// mock server in tests.
+func WithChatBaseURL(chatBaseURL string) Option {
+	return func(c *HTTPClient) {
+		c.chatBaseURL = chatBaseURL
+	}
+}
+
+// WithTransport overrides the http.Client's RoundTripper, e.g. to point
+// requests through a proxy or swap in a mock transport for tests.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *HTTPClient) {
+		c.httpClient.Transport = transport
+	}
+}
+
+// WithLogger overrides the default no-op logger, wrapping it with
+// NewRedactingLogger so NCFA tokens and chat message content never reach
+// the underlying sink unredacted.
+func WithLogger(logger *zap.SugaredLogger) Option {
+	return func(c *HTTPClient) {
+		c.logger = NewRedactingLogger(logger)
+	}
+}
+
+// NewClientWithOptions builds an HTTPClient with DefaultRetryPolicy and a
+// no-op logger, applying opts on top. NewClient is a convenience wrapper
+// around this for the common case of just needing a logger.
+func NewClientWithOptions(ncfaToken string, opts ...Option) *HTTPClient {
+	c := &HTTPClient{
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		baseURL:     "https://www.geoguessr.com/api/v3",
+		chatBaseURL: "https://www.geoguessr.com/api/v4",
+		ncfaToken:   ncfaToken,
+		logger:      NewRedactingLogger(zap.NewNop().Sugar()),
+		retryPolicy: DefaultRetryPolicy(),
+		chatMark:    make(map[string]time.Time),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	// LoginWithPassword/SaveSession/LoadSession need a jar to capture and
+	// replay the _ncfa cookie; callers using WithHTTPClient can still
+	// supply their own.
+	if c.httpClient.Jar == nil {
+		jar, _ := cookiejar.New(nil)
+		c.httpClient.Jar = jar
+	}
+
+	return c
+}
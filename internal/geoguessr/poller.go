@@ -0,0 +1,157 @@
+package geoguessr
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// PollChat long-polls a single user's conversation every interval,
+// invoking handler for each message that's new since the last poll. The
+// first poll for a user only seeds the high-water mark; it does not
+// replay pre-existing history. PollChat runs until ctx is cancelled,
+// reading the conversation fails, or handler returns an error.
+func (c *HTTPClient) PollChat(ctx context.Context, userID string, interval time.Duration, handler func(ChatMessage) error) error {
+	if err := c.pollChatOnce(userID, handler); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := c.pollChatOnce(userID, handler); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// pollChatOnce reads userID's conversation, dispatches messages newer than
+// the stored high-water mark to handler, then advances the mark to the
+// newest SentAt seen. Messages with an unparseable SentAt are skipped,
+// since there's no way to place them relative to the mark.
+func (c *HTTPClient) pollChatOnce(userID string, handler func(ChatMessage) error) error {
+	messages, err := c.ReadChatMessages(userID)
+	if err != nil {
+		return err
+	}
+
+	c.chatMarkMu.Lock()
+	mark, seeded := c.chatMark[userID]
+	c.chatMarkMu.Unlock()
+
+	newMark := mark
+	var fresh []ChatMessage
+	for _, message := range messages {
+		sentAt, err := time.Parse(time.RFC3339, message.SentAt)
+		if err != nil {
+			continue
+		}
+		if seeded && sentAt.After(mark) {
+			fresh = append(fresh, message)
+		}
+		if sentAt.After(newMark) {
+			newMark = sentAt
+		}
+	}
+
+	c.chatMarkMu.Lock()
+	c.chatMark[userID] = newMark
+	c.chatMarkMu.Unlock()
+
+	for _, message := range fresh {
+		if err := handler(message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const (
+	conversationDiscoveryInterval = 30 * time.Second
+	defaultChatPollInterval       = 5 * time.Second
+	maxConcurrentChatPolls        = 5
+)
+
+// PollAllConversations discovers users to watch from two sources -
+// GetPendingFriendRequests (requests not yet accepted) and GetFriends
+// (conversations already established) - since a user drops out of the
+// pending list the moment their request is accepted and would otherwise
+// never be discovered again. It runs a PollChat goroutine per user, bounded
+// to maxConcurrentChatPolls concurrent pollers. Each discovered message is
+// passed to handler along with the user it came from. It runs until ctx is
+// cancelled.
+func (c *HTTPClient) PollAllConversations(ctx context.Context, handler func(userID string, message ChatMessage) error) error {
+	sem := make(chan struct{}, maxConcurrentChatPolls)
+
+	var mu sync.Mutex
+	active := make(map[string]context.CancelFunc)
+
+	startPoll := func(userID string) {
+		mu.Lock()
+		if _, exists := active[userID]; exists {
+			mu.Unlock()
+			return
+		}
+		userCtx, cancel := context.WithCancel(ctx)
+		active[userID] = cancel
+		mu.Unlock()
+
+		go func() {
+			defer func() {
+				mu.Lock()
+				delete(active, userID)
+				mu.Unlock()
+			}()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			err := c.PollChat(userCtx, userID, defaultChatPollInterval, func(message ChatMessage) error {
+				return handler(userID, message)
+			})
+			if err != nil && !errors.Is(err, context.Canceled) {
+				c.logger.Warnf("chat poller for user %s stopped: %v", userID, err)
+			}
+		}()
+	}
+
+	discover := func() {
+		pending, err := c.GetPendingFriendRequests()
+		if err != nil {
+			c.logger.Warnf("failed to enumerate pending friend requests: %v", err)
+		}
+		for _, userID := range pending {
+			startPoll(userID)
+		}
+
+		friends, err := c.GetFriends()
+		if err != nil {
+			c.logger.Warnf("failed to enumerate friends: %v", err)
+			return
+		}
+		for _, friend := range friends {
+			startPoll(friend.UserID)
+		}
+	}
+
+	discover()
+
+	ticker := time.NewTicker(conversationDiscoveryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			discover()
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package geoguessr
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures the truncated exponential backoff with jitter used
+// by doWithRetry. Network errors, 5xx responses, 408s, and 429s are
+// retried; other 4xx responses are returned to the caller immediately.
+type RetryPolicy struct {
+	InitialInterval time.Duration
+	Multiplier      float64
+	MaxInterval     time.Duration
+	MaxElapsedTime  time.Duration
+	Jitter          float64
+}
+
+// DefaultRetryPolicy is tuned for Geoguessr's observed rate limiting: a
+// short initial backoff, capped growth, and a two-minute ceiling so a
+// single stuck call can't hang a background poller indefinitely.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		InitialInterval: 500 * time.Millisecond,
+		Multiplier:      1.5,
+		MaxInterval:     30 * time.Second,
+		MaxElapsedTime:  2 * time.Minute,
+		Jitter:          0.5,
+	}
+}
+
+// backoff returns the delay before retry attempt n (0-indexed), per
+// min(MaxInterval, InitialInterval * Multiplier^n) jittered by +/-Jitter/2.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	interval := float64(p.InitialInterval) * math.Pow(p.Multiplier, float64(attempt))
+	if max := float64(p.MaxInterval); interval > max {
+		interval = max
+	}
+	jittered := interval * (1 + rand.Float64()*p.Jitter - p.Jitter/2)
+	return time.Duration(jittered)
+}
+
+func isRetryableStatus(status int) bool {
+	if status == http.StatusRequestTimeout || status == http.StatusTooManyRequests {
+		return true
+	}
+	return status >= 500
+}
+
+// doWithRetry executes requests built by newReq under c.retryPolicy. newReq
+// is invoked again on every attempt so request bodies consumed by the
+// previous attempt are rebuilt fresh. Retry-After is honored when present
+// on a retryable response, otherwise the policy's backoff schedule is used.
+// The final response (successful, non-retryable, or the last retryable one
+// once MaxElapsedTime is exceeded) is returned for the caller to interpret,
+// matching the status-code handling every call site already does.
+func (c *HTTPClient) doWithRetry(newReq func() (*http.Request, error)) (*http.Response, []byte, error) {
+	policy := c.retryPolicy
+	start := time.Now()
+
+	for attempt := 0; ; attempt++ {
+		req, err := newReq()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := c.httpClient.Do(req)
+
+		var body []byte
+		var lastErr error
+		if err != nil {
+			lastErr = err
+		} else {
+			body, err = io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				lastErr = fmt.Errorf("failed to read response body: %w", err)
+			} else if !isRetryableStatus(resp.StatusCode) {
+				return resp, body, nil
+			} else {
+				lastErr = fmt.Errorf("retryable status %d: %s", resp.StatusCode, string(body))
+			}
+		}
+
+		elapsed := time.Since(start)
+		if elapsed >= policy.MaxElapsedTime {
+			if resp != nil {
+				return resp, body, nil
+			}
+			return nil, nil, fmt.Errorf("giving up after %s: %w", elapsed.Round(time.Millisecond), lastErr)
+		}
+
+		wait := policy.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				wait = retryAfter
+			}
+		}
+		time.Sleep(wait)
+	}
+}
+
+func parseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		return time.Until(when), true
+	}
+	return 0, false
+}
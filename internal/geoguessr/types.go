@@ -1,5 +1,6 @@
 package geoguessr
 
+import "time"
 
 type Friend struct {
 	UserID string `json:"userId"`
@@ -27,4 +28,25 @@ type ChatMessage struct {
 type ChatResponse struct {
 	RoomID   string        `json:"roomId"`
 	Messages []ChatMessage `json:"messages"`
+}
+
+// Challenge describes a verification prompt to send via chat and the reply
+// pattern that counts as a successful response.
+type Challenge struct {
+	// Prompt is sent to the user via SendChatMessage before polling begins.
+	Prompt string
+	// ExpectedPattern is a regexp matched against each reply's TextPayload.
+	ExpectedPattern string
+	// PollInterval is how often chat is re-read while waiting for a reply.
+	// Defaults to 5 seconds when zero.
+	PollInterval time.Duration
+	// Timeout bounds how long VerifyUser waits for a matching reply before
+	// giving up. Defaults to 2 minutes when zero.
+	Timeout time.Duration
+}
+
+// VerificationResult is the outcome of a VerifyUser call.
+type VerificationResult struct {
+	Verified       bool
+	MatchedMessage string
 }
\ No newline at end of file
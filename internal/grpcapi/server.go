@@ -0,0 +1,155 @@
+// Package grpcapi exposes the verification service over gRPC, alongside the
+// REST API in internal/api, for backend-to-backend integrators who would
+// rather hold a long-lived connection than run a webhook receiver.
+package grpcapi
+
+import (
+	"context"
+	"net"
+
+	"github.com/lonanche/geo-verification/internal/verification"
+	"github.com/lonanche/geo-verification/proto/verificationpb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements verificationpb.GeoVerificationServer against an
+// existing verification.Service, sharing its session store and background
+// pollers with the REST API.
+type Server struct {
+	verificationpb.UnimplementedGeoVerificationServer
+
+	service *verification.Service
+	logger  *zap.Logger
+}
+
+// NewServer returns a Server backed by service.
+func NewServer(service *verification.Service, logger *zap.Logger) *Server {
+	return &Server{service: service, logger: logger}
+}
+
+func (s *Server) StartVerification(ctx context.Context, req *verificationpb.StartVerificationRequest) (*verificationpb.StartVerificationResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	clientIP := ""
+	if peerAddr, ok := peer.FromContext(ctx); ok && peerAddr.Addr != nil {
+		clientIP = stripGRPCPort(peerAddr.Addr.String())
+	}
+
+	session, err := s.service.StartVerification(req.GetUserId(), req.GetCallbackUrl(), req.GetWebhookSecret(), clientIP)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	message := "Friend request already accepted. Send the code via GeoGuessr chat to verify."
+	if session.State == verification.StatePendingFriend {
+		message = "Add the bot as a friend on GeoGuessr, then send the verification code via chat."
+	}
+
+	return &verificationpb.StartVerificationResponse{
+		SessionId:        session.ID,
+		VerificationCode: session.Code,
+		ExpiresAt:        session.ExpiresAt.Format(timeLayout),
+		Message:          message,
+	}, nil
+}
+
+func (s *Server) GetVerificationStatus(ctx context.Context, req *verificationpb.GetVerificationStatusRequest) (*verificationpb.VerificationStatus, error) {
+	if req.GetSessionId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	session, err := s.service.GetSessionStatus(req.GetSessionId())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return toProtoStatus(session), nil
+}
+
+// WatchVerification streams state transitions for a session until it
+// reaches a terminal state (VERIFIED or EXPIRED), or the client disconnects.
+func (s *Server) WatchVerification(req *verificationpb.WatchVerificationRequest, stream verificationpb.GeoVerification_WatchVerificationServer) error {
+	if req.GetSessionId() == "" {
+		return status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	session, err := s.service.GetSessionStatus(req.GetSessionId())
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if err := stream.Send(toProtoStatus(session)); err != nil {
+		return err
+	}
+
+	states, ok := s.service.Watch(req.GetSessionId())
+	if !ok {
+		return status.Error(codes.NotFound, "session not found or expired")
+	}
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case state, open := <-states:
+			if !open {
+				return nil
+			}
+			// Build the status from the state just received rather than
+			// re-fetching the session: a terminal state (e.g. EXPIRED) may
+			// already be unreachable via GetSessionStatus by the time we'd
+			// look it up again, since the store treats a passed ExpiresAt as
+			// "doesn't exist". The client still needs to see the terminal
+			// status before the stream closes.
+			session.State = state
+			if err := stream.Send(toProtoStatus(session)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+const timeLayout = "2006-01-02T15:04:05Z07:00"
+
+// stripGRPCPort reduces a peer address like "203.0.113.7:54321" to the bare
+// IP, matching what the HTTP middleware passes to checkRateLimit. gRPC has
+// no reverse-proxy header convention analogous to X-Forwarded-For, so the
+// peer address is used as-is.
+func stripGRPCPort(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+func toProtoStatus(session *verification.Session) *verificationpb.VerificationStatus {
+	return &verificationpb.VerificationStatus{
+		SessionId: session.ID,
+		Username:  session.Username,
+		State:     toProtoState(session.State),
+		ExpiresAt: session.ExpiresAt.Format(timeLayout),
+		CreatedAt: session.CreatedAt.Format(timeLayout),
+	}
+}
+
+// toProtoState maps the verification package's internal state to the proto
+// enum, falling back to AWAITING_CODE for a zero-value state (e.g. a
+// session stored before this field existed).
+func toProtoState(state verification.VerificationState) verificationpb.VerificationState {
+	switch state {
+	case verification.StatePendingFriend:
+		return verificationpb.VerificationState_PENDING_FRIEND
+	case verification.StateVerified:
+		return verificationpb.VerificationState_VERIFIED
+	case verification.StateExpired:
+		return verificationpb.VerificationState_EXPIRED
+	default:
+		return verificationpb.VerificationState_AWAITING_CODE
+	}
+}
@@ -0,0 +1,29 @@
+package logger
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestNewObserved(t *testing.T) {
+	log, observed := NewObserved("geo-verification-test")
+
+	log.Info("session created", zap.String("session_id", "abc123"))
+
+	entries := observed.All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d logged entries, want 1", len(entries))
+	}
+
+	entry := entries[0]
+	if entry.Message != "session created" {
+		t.Errorf("message = %q, want %q", entry.Message, "session created")
+	}
+	if got := entry.ContextMap()["service"]; got != "geo-verification-test" {
+		t.Errorf("service field = %v, want %q", got, "geo-verification-test")
+	}
+	if got := entry.ContextMap()["session_id"]; got != "abc123" {
+		t.Errorf("session_id field = %v, want %q", got, "abc123")
+	}
+}
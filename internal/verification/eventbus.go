@@ -0,0 +1,61 @@
+package verification
+
+import "sync"
+
+// VerificationState mirrors the states exposed over the gRPC
+// WatchVerification stream, as well as internal state transitions.
+type VerificationState string
+
+const (
+	StatePendingFriend VerificationState = "PENDING_FRIEND"
+	StateAwaitingCode  VerificationState = "AWAITING_CODE"
+	StateVerified      VerificationState = "VERIFIED"
+	StateExpired       VerificationState = "EXPIRED"
+)
+
+func isTerminal(state VerificationState) bool {
+	return state == StateVerified || state == StateExpired
+}
+
+// eventBus fans out per-session state transitions to any number of
+// subscribers (e.g. gRPC WatchVerification streams), closing each
+// subscriber's channel once the session reaches a terminal state.
+type eventBus struct {
+	mu       sync.Mutex
+	watchers map[string][]chan VerificationState
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{watchers: make(map[string][]chan VerificationState)}
+}
+
+// Subscribe registers a new watcher for sessionID. The returned channel is
+// closed automatically once a terminal state is published.
+func (b *eventBus) Subscribe(sessionID string) <-chan VerificationState {
+	ch := make(chan VerificationState, 4)
+	b.mu.Lock()
+	b.watchers[sessionID] = append(b.watchers[sessionID], ch)
+	b.mu.Unlock()
+	return ch
+}
+
+// Publish fans state out to every current subscriber for sessionID. It never
+// blocks: a slow or abandoned subscriber just misses intermediate states.
+func (b *eventBus) Publish(sessionID string, state VerificationState) {
+	b.mu.Lock()
+	chans := b.watchers[sessionID]
+	if isTerminal(state) {
+		delete(b.watchers, sessionID)
+	}
+	b.mu.Unlock()
+
+	for _, ch := range chans {
+		select {
+		case ch <- state:
+		default:
+		}
+		if isTerminal(state) {
+			close(ch)
+		}
+	}
+}
@@ -2,34 +2,57 @@ package verification
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/lonanche/geo-verification/internal/geoguessr"
+	"go.uber.org/zap"
 	"golang.org/x/time/rate"
 )
 
-type Logger interface {
-	Printf(format string, v ...interface{})
-}
+// pollerLeaseTTL bounds how long a node holds the background-poller lease
+// before another replica can take over if it stops renewing (e.g. crash).
+const pollerLeaseTTL = 45 * time.Second
+
+// webhookWorkerPoolSize bounds how many webhook deliveries are attempted
+// concurrently per poller tick.
+const webhookWorkerPoolSize = 5
 
 type Service struct {
-	sessionStore  *SessionStore
-	geoClient     geoguessr.Client
-	rateLimiters  map[string]*rate.Limiter
-	rateMutex     sync.RWMutex
+	sessionStore SessionStore
+	geoClient    geoguessr.Client
+	rateLimiters map[string]*rate.Limiter
+	rateMutex    sync.RWMutex
+	httpClient   *http.Client
+	logger       *zap.Logger
+	instanceID   string // identifies this replica when contending for the poller lease
+
+	// configMu guards the mutable subset of configuration that Reconfigure
+	// swaps atomically on SIGHUP, without restarting the process.
+	configMu      sync.RWMutex
 	rateLimitRate rate.Limit
 	expiryTime    time.Duration
-	httpClient    *http.Client
-	friends       map[string]bool // Track accepted friends locally
-	friendsMutex  sync.RWMutex
 	allowedHosts  map[string]bool // Allowed callback hosts
-	logger        Logger
+
+	// webhookSigningSecret signs deliveries for sessions that didn't register
+	// their own callback secret.
+	webhookSigningSecret string
+
+	// events fans session state transitions out to gRPC WatchVerification
+	// streams (see internal/grpcapi).
+	events *eventBus
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
 }
 
 type CallbackPayload struct {
@@ -39,45 +62,189 @@ type CallbackPayload struct {
 	Timestamp string `json:"timestamp"`
 }
 
-func NewService(geoClient geoguessr.Client, rateLimitPerHour int, expiryMinutes time.Duration, allowedCallbackHosts string, logger Logger) *Service {
-	rateLimitRate := rate.Limit(float64(rateLimitPerHour) / 3600.0)
-
-	// Parse allowed hosts from comma-separated string
-	allowedHostsMap := make(map[string]bool)
-	if allowedCallbackHosts != "" {
-		hosts := strings.Split(allowedCallbackHosts, ",")
-		for _, host := range hosts {
-			allowedHostsMap[strings.TrimSpace(host)] = true
-		}
-	}
+func NewService(geoClient geoguessr.Client, sessionStore SessionStore, rateLimitPerHour int, expiryMinutes time.Duration, allowedCallbackHosts, webhookSigningSecret string, logger *zap.Logger) *Service {
+	ctx, cancel := context.WithCancel(context.Background())
 
 	service := &Service{
-		sessionStore:  NewSessionStore(),
+		sessionStore:  sessionStore,
 		geoClient:     geoClient,
 		rateLimiters:  make(map[string]*rate.Limiter),
-		rateLimitRate: rateLimitRate,
+		rateLimitRate: rate.Limit(float64(rateLimitPerHour) / 3600.0),
 		expiryTime:    expiryMinutes,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		friends:      make(map[string]bool),
-		allowedHosts: allowedHostsMap,
-		logger:       logger,
+		allowedHosts:         parseAllowedHosts(allowedCallbackHosts),
+		logger:               logger,
+		instanceID:           uuid.New().String(),
+		webhookSigningSecret: webhookSigningSecret,
+		events:               newEventBus(),
+		ctx:                  ctx,
+		cancel:               cancel,
 	}
 
-	// Start background services
-	go service.startFriendRequestAcceptanceService()
-	go service.startChatMonitoringService()
-	go service.startExpirationMonitoringService()
+	// Start background services. Each one re-acquires/renews the cluster-wide
+	// poller lease on every tick and is a no-op elsewhere; with
+	// MemorySessionStore the lease is always held locally. They stop when
+	// ctx is cancelled by Close.
+	service.goBackground(service.startFriendRequestAcceptanceService)
+	service.goBackground(service.startChatMonitoringService)
+	service.goBackground(service.startExpirationMonitoringService)
+	service.goBackground(service.startWebhookDeliveryService)
+
+	if events := sessionStore.Events(); events != nil {
+		service.goBackground(func() { service.consumeRemoteEvents(events) })
+	}
 
 	return service
 }
 
-func (s *Service) StartVerification(userID, callbackURL string) (*Session, error) {
+func parseAllowedHosts(allowedCallbackHosts string) map[string]bool {
+	allowedHostsMap := make(map[string]bool)
+	if allowedCallbackHosts != "" {
+		hosts := strings.Split(allowedCallbackHosts, ",")
+		for _, host := range hosts {
+			allowedHostsMap[strings.TrimSpace(host)] = true
+		}
+	}
+	return allowedHostsMap
+}
+
+// goBackground runs fn in a goroutine tracked by the service's WaitGroup, so
+// Close can wait for every poller to notice ctx cancellation and exit.
+func (s *Service) goBackground(fn func()) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		fn()
+	}()
+}
+
+// Close stops all background pollers and releases the session store. It
+// waits up to the deadline on ctx for pollers to exit, then makes one final
+// attempt to drain the webhook retry queue before returning.
+func (s *Service) Close(ctx context.Context) error {
+	s.logger.Info("shutting down verification service")
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.logger.Warn("timed out waiting for background pollers to stop")
+	}
+
+	s.processPendingDeliveries()
+
+	return s.sessionStore.Close()
+}
+
+// Reconfigure atomically swaps the mutable subset of configuration (rate
+// limit, code expiry, allowed callback hosts) without restarting the
+// process, for SIGHUP-driven reloads.
+func (s *Service) Reconfigure(rateLimitPerHour int, expiryMinutes time.Duration, allowedCallbackHosts string) {
+	s.configMu.Lock()
+	s.rateLimitRate = rate.Limit(float64(rateLimitPerHour) / 3600.0)
+	s.expiryTime = expiryMinutes
+	s.allowedHosts = parseAllowedHosts(allowedCallbackHosts)
+	s.configMu.Unlock()
+
+	s.logger.Info("configuration reloaded",
+		zap.Int("rate_limit_per_hour", rateLimitPerHour),
+		zap.Duration("code_expiry", expiryMinutes),
+		zap.String("allowed_callback_hosts", allowedCallbackHosts),
+	)
+}
+
+// consumeRemoteEvents replays session state transitions observed from other
+// replicas onto the local eventBus, so a gRPC WatchVerification stream held
+// by this node wakes up for sessions whose transition actually happened on
+// a different replica (e.g. the poller leader), instead of just sitting in
+// the logs.
+func (s *Service) consumeRemoteEvents(events <-chan SessionEvent) {
+	for {
+		var event SessionEvent
+		select {
+		case <-s.ctx.Done():
+			return
+		case received, open := <-events:
+			if !open {
+				return
+			}
+			event = received
+		}
+
+		s.logger.Info("observed remote session event",
+			zap.String("session_id", event.SessionID),
+			zap.String("user_id", event.Username),
+			zap.String("status", event.Status),
+		)
+
+		state, ok := eventStatusState(event.Status)
+		if !ok {
+			s.logger.Warn("unrecognized remote session event status",
+				zap.String("session_id", event.SessionID),
+				zap.String("status", event.Status),
+			)
+			continue
+		}
+		s.events.Publish(event.SessionID, state)
+	}
+}
+
+// eventStatusState is the inverse of stateEventStatus, translating a
+// SessionEvent.Status received over pub/sub back into the VerificationState
+// the local eventBus deals in.
+func eventStatusState(status string) (VerificationState, bool) {
+	switch status {
+	case "pending_friend":
+		return StatePendingFriend, true
+	case "awaiting_code":
+		return StateAwaitingCode, true
+	case "verified":
+		return StateVerified, true
+	case "expired":
+		return StateExpired, true
+	default:
+		return "", false
+	}
+}
+
+// isPollerLeader attempts to acquire or renew the cluster-wide poller lease.
+// Only the node holding it runs the friend/chat/expiration pollers, so
+// replicas behind a load balancer don't duplicate GeoGuessr API calls.
+func (s *Service) isPollerLeader() bool {
+	leader, err := s.sessionStore.TryAcquirePollerLease(s.instanceID, pollerLeaseTTL)
+	if err != nil {
+		s.logger.Error("failed to evaluate poller lease", zap.Error(err))
+		return false
+	}
+	return leader
+}
+
+func (s *Service) StartVerification(userID, callbackURL, webhookSecret, clientIP string) (*Session, error) {
+	s.logger.Info("verification start requested",
+		zap.String("user_id", userID),
+		zap.String("client_ip", clientIP),
+	)
+
 	if !s.checkRateLimit(userID) {
 		return nil, fmt.Errorf("rate limit exceeded for user %s", userID)
 	}
 
+	if clientIP != "" && !s.checkRateLimit(ipRateLimitKey(clientIP)) {
+		s.logger.Warn("rate limit exceeded for client IP",
+			zap.String("user_id", userID),
+			zap.String("client_ip", clientIP),
+		)
+		return nil, fmt.Errorf("rate limit exceeded for client IP %s", clientIP)
+	}
+
 	// Validate callback URL if provided (localhost only by default)
 	if callbackURL != "" {
 		if err := s.validateCallbackURL(callbackURL); err != nil {
@@ -86,22 +253,24 @@ func (s *Service) StartVerification(userID, callbackURL string) (*Session, error
 	}
 
 	// Check for existing active session and remove it
-	if existingSession := s.getActiveSession(userID); existingSession != nil {
-		s.logger.Printf("Removing existing active session %s for user %s", existingSession.ID, userID)
+	if existingSession, exists := s.sessionStore.GetActiveByUsername(userID); exists {
+		s.logger.Info("removing existing active session",
+			zap.String("session_id", existingSession.ID),
+			zap.String("user_id", userID),
+		)
 		s.sessionStore.Delete(existingSession.ID)
 
-		// Clean up local friend status for the old session
-		s.friendsMutex.Lock()
-		delete(s.friends, userID)
-		s.friendsMutex.Unlock()
-
 		// Send expiration webhook for the old session if it has a callback URL
 		if existingSession.CallbackURL != "" {
-			go s.sendWebhook(existingSession, "expired")
+			s.enqueueWebhook(existingSession, "expired")
 		}
 	}
 
-	session, err := s.sessionStore.Create(userID, callbackURL, s.expiryTime)
+	s.configMu.RLock()
+	expiryTime := s.expiryTime
+	s.configMu.RUnlock()
+
+	session, err := s.sessionStore.Create(userID, callbackURL, webhookSecret, expiryTime)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
@@ -109,7 +278,10 @@ func (s *Service) StartVerification(userID, callbackURL string) (*Session, error
 	// Check if user has added us as friend
 	isFriend, err := s.geoClient.IsFriend(userID)
 	if err != nil {
-		s.logger.Printf("Could not check friend status for %s: %v", userID, err)
+		s.logger.Warn("could not check friend status",
+			zap.String("user_id", userID),
+			zap.Error(err),
+		)
 		s.sessionStore.Delete(session.ID)
 		return nil, fmt.Errorf("failed to check friend status: %w", err)
 	}
@@ -117,12 +289,19 @@ func (s *Service) StartVerification(userID, callbackURL string) (*Session, error
 	if !isFriend {
 		// User is not friends yet - return session with code for them to send
 		// Background service will auto-accept friend request when they send it
-		s.logger.Printf("User %s not friends yet, session created with code for them to send", userID)
+		s.logger.Info("user not friends yet, session created with code for them to send",
+			zap.String("user_id", userID),
+			zap.String("session_id", session.ID),
+		)
+		// Session.State already defaults to StatePendingFriend from Create.
+		s.events.Publish(session.ID, StatePendingFriend)
+
 		sessionResponse := &Session{
 			ID:        session.ID,
 			Username:  session.Username,
 			Code:      session.Code, // Include code for user to send to bot
 			Verified:  session.Verified,
+			State:     session.State,
 			ExpiresAt: session.ExpiresAt,
 			CreatedAt: session.CreatedAt,
 		}
@@ -130,18 +309,26 @@ func (s *Service) StartVerification(userID, callbackURL string) (*Session, error
 	}
 
 	// If we're already friends, user can immediately start sending the code
-	s.logger.Printf("User %s is already friends, can start verification immediately", userID)
+	s.logger.Info("user is already friends, can start verification immediately",
+		zap.String("user_id", userID),
+		zap.String("session_id", session.ID),
+	)
 
-	// Mark user as friend locally since they're already a friend
-	s.friendsMutex.Lock()
-	s.friends[userID] = true
-	s.friendsMutex.Unlock()
+	if err := s.sessionStore.SetState(session.ID, StateAwaitingCode); err != nil {
+		s.logger.Warn("failed to persist awaiting-code state",
+			zap.String("session_id", session.ID),
+			zap.Error(err),
+		)
+	}
+	session.State = StateAwaitingCode
+	s.events.Publish(session.ID, StateAwaitingCode)
 
 	sessionResponse := &Session{
 		ID:        session.ID,
 		Username:  session.Username,
 		Code:      session.Code, // Include code for user to send to bot
 		Verified:  session.Verified,
+		State:     session.State,
 		ExpiresAt: session.ExpiresAt,
 		CreatedAt: session.CreatedAt,
 	}
@@ -149,6 +336,17 @@ func (s *Service) StartVerification(userID, callbackURL string) (*Session, error
 	return sessionResponse, nil
 }
 
+// Watch subscribes to state transitions for sessionID, for the gRPC
+// WatchVerification RPC. The returned channel closes once the session
+// reaches a terminal state (VERIFIED or EXPIRED); ok is false if the
+// session does not exist.
+func (s *Service) Watch(sessionID string) (<-chan VerificationState, bool) {
+	if _, exists := s.sessionStore.Get(sessionID); !exists {
+		return nil, false
+	}
+	return s.events.Subscribe(sessionID), true
+}
+
 func (s *Service) GetSessionStatus(sessionID string) (*Session, error) {
 	session, exists := s.sessionStore.Get(sessionID)
 	if !exists {
@@ -159,6 +357,7 @@ func (s *Service) GetSessionStatus(sessionID string) (*Session, error) {
 		ID:        session.ID,
 		Username:  session.Username,
 		Verified:  session.Verified,
+		State:     session.State,
 		ExpiresAt: session.ExpiresAt,
 		CreatedAt: session.CreatedAt,
 	}
@@ -167,19 +366,31 @@ func (s *Service) GetSessionStatus(sessionID string) (*Session, error) {
 }
 
 func (s *Service) startFriendRequestAcceptanceService() {
-	s.logger.Printf("Starting background friend request acceptance service")
+	s.logger.Info("starting background friend request acceptance service")
 
 	ticker := time.NewTicker(30 * time.Second) // Poll every 30 seconds
 	defer ticker.Stop()
 
-	for range ticker.C {
-		s.processPendingFriendRequests()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.isPollerLeader() {
+				continue
+			}
+			s.processPendingFriendRequests()
+		}
 	}
 }
 
 func (s *Service) processPendingFriendRequests() {
 	// Check if there are any active sessions first
-	activeSessions := s.getActiveSessions()
+	activeSessions, err := s.sessionStore.ListActive()
+	if err != nil {
+		s.logger.Error("error listing active sessions", zap.Error(err))
+		return
+	}
 	if len(activeSessions) == 0 {
 		// No active verification sessions, skip checking friend requests
 		return
@@ -188,7 +399,7 @@ func (s *Service) processPendingFriendRequests() {
 	// Get pending friend requests
 	pendingRequests, err := s.geoClient.GetPendingFriendRequests()
 	if err != nil {
-		s.logger.Printf("Error getting pending friend requests: %v", err)
+		s.logger.Error("error getting pending friend requests", zap.Error(err))
 		return
 	}
 
@@ -196,69 +407,71 @@ func (s *Service) processPendingFriendRequests() {
 		return
 	}
 
-	s.logger.Printf("Found %d pending friend requests", len(pendingRequests))
+	s.logger.Info("found pending friend requests", zap.Int("count", len(pendingRequests)))
 
 	// Check which users have active verification sessions
 	for _, userID := range pendingRequests {
-		if s.hasActiveSession(userID) {
-			s.logger.Printf("User %s has active verification session, accepting friend request", userID)
+		if _, exists := s.sessionStore.GetActiveByUsername(userID); exists {
+			s.logger.Info("user has active verification session, accepting friend request",
+				zap.String("user_id", userID),
+			)
 			if err := s.geoClient.AcceptFriendRequest(userID); err != nil {
-				s.logger.Printf("Error accepting friend request from %s: %v", userID, err)
+				s.logger.Error("error accepting friend request",
+					zap.String("user_id", userID),
+					zap.Error(err),
+				)
 			} else {
-				s.logger.Printf("Successfully accepted friend request from %s", userID)
-
-				// Mark user as friend locally
-				s.friendsMutex.Lock()
-				s.friends[userID] = true
-				s.friendsMutex.Unlock()
+				s.logger.Info("successfully accepted friend request", zap.String("user_id", userID))
 
 				// Friend request accepted - user can now send their verification code
-				s.logger.Printf("Friend request accepted for %s, user can now send verification code", userID)
+				s.logger.Info("friend request accepted, user can now send verification code",
+					zap.String("user_id", userID),
+				)
+
+				if session, exists := s.sessionStore.GetActiveByUsername(userID); exists {
+					if err := s.sessionStore.SetState(session.ID, StateAwaitingCode); err != nil {
+						s.logger.Warn("failed to persist awaiting-code state",
+							zap.String("session_id", session.ID),
+							zap.Error(err),
+						)
+					}
+					s.events.Publish(session.ID, StateAwaitingCode)
+				}
 			}
 		} else {
-			s.logger.Printf("User %s has no active verification session, skipping friend request", userID)
+			s.logger.Debug("user has no active verification session, skipping friend request",
+				zap.String("user_id", userID),
+			)
 		}
 	}
 }
 
-func (s *Service) hasActiveSession(userID string) bool {
-	s.sessionStore.mutex.RLock()
-	defer s.sessionStore.mutex.RUnlock()
-
-	for _, session := range s.sessionStore.sessions {
-		if session.Username == userID && time.Now().Before(session.ExpiresAt) {
-			return true
-		}
-	}
-	return false
-}
-
-func (s *Service) getActiveSession(userID string) *Session {
-	s.sessionStore.mutex.RLock()
-	defer s.sessionStore.mutex.RUnlock()
-
-	for _, session := range s.sessionStore.sessions {
-		if session.Username == userID && time.Now().Before(session.ExpiresAt) {
-			return session
-		}
-	}
-	return nil
-}
-
 func (s *Service) startChatMonitoringService() {
-	s.logger.Printf("Starting background chat monitoring service")
+	s.logger.Info("starting background chat monitoring service")
 
 	ticker := time.NewTicker(30 * time.Second) // Poll every 30 seconds
 	defer ticker.Stop()
 
-	for range ticker.C {
-		s.monitorChatMessages()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.isPollerLeader() {
+				continue
+			}
+			s.monitorChatMessages()
+		}
 	}
 }
 
 func (s *Service) monitorChatMessages() {
 	// Get all active sessions
-	activeSessions := s.getActiveSessions()
+	activeSessions, err := s.sessionStore.ListActive()
+	if err != nil {
+		s.logger.Error("error listing active sessions", zap.Error(err))
+		return
+	}
 
 	// If no active sessions, skip chat monitoring
 	if len(activeSessions) == 0 {
@@ -270,38 +483,31 @@ func (s *Service) monitorChatMessages() {
 			continue // Skip already verified sessions
 		}
 
-		// Check if user is a friend locally before trying to read chat messages
-		if !s.isLocalFriend(session.Username) {
-			// User is not a friend yet, skip reading chat messages
+		// Re-derive friend status from the API on every tick rather than
+		// trusting a per-process cache: StartVerification can run on any
+		// replica behind the load balancer, while this poller only runs on
+		// the poller-leader replica, so a local cache here would never learn
+		// about a friendship confirmed on another node and the session would
+		// hang forever.
+		isFriend, err := s.geoClient.IsFriend(session.Username)
+		if err != nil {
+			s.logger.Error("error checking friend status",
+				zap.String("user_id", session.Username),
+				zap.Error(err),
+			)
+			continue
+		}
+		if !isFriend {
 			continue
 		}
 
 		// Read chat messages from this user
 		messages, err := s.geoClient.ReadChatMessages(session.Username)
 		if err != nil {
-			// Check if it's a 404 error (user might not be friend anymore)
-			if strings.Contains(err.Error(), "404") {
-				s.logger.Printf("Got 404 reading chat from %s, checking actual friend status via API", session.Username)
-
-				// Check API to see if user is still a friend
-				isFriend, apiErr := s.geoClient.IsFriend(session.Username)
-				if apiErr != nil {
-					s.logger.Printf("Error checking friend status for %s: %v", session.Username, apiErr)
-				} else {
-					// Update local friend status based on API response
-					s.friendsMutex.Lock()
-					s.friends[session.Username] = isFriend
-					s.friendsMutex.Unlock()
-
-					if !isFriend {
-						s.logger.Printf("User %s is no longer a friend, updated local status", session.Username)
-					} else {
-						s.logger.Printf("User %s is still a friend according to API, but chat read failed", session.Username)
-					}
-				}
-			} else {
-				s.logger.Printf("Error reading chat messages from %s: %v", session.Username, err)
-			}
+			s.logger.Error("error reading chat messages",
+				zap.String("user_id", session.Username),
+				zap.Error(err),
+			)
 			continue
 		}
 
@@ -309,20 +515,27 @@ func (s *Service) monitorChatMessages() {
 		for _, message := range messages {
 			// Only check messages from the user to us (not our messages to them)
 			if message.SourceID == session.Username && message.TextPayload == session.Code {
-				s.logger.Printf("Verification code received from %s: %s", session.Username, session.Code)
+				s.logger.Info("verification code received",
+					zap.String("user_id", session.Username),
+					zap.String("session_id", session.ID),
+				)
 
 				// Mark session as verified
+				if err := s.sessionStore.MarkVerified(session.ID); err != nil {
+					s.logger.Error("error marking session verified",
+						zap.String("session_id", session.ID),
+						zap.Error(err),
+					)
+					continue
+				}
 				session.Verified = true
-				s.logger.Printf("User %s verified successfully!", session.Username)
-
-				// Clean up local friend status after successful verification
-				s.friendsMutex.Lock()
-				delete(s.friends, session.Username)
-				s.friendsMutex.Unlock()
+				session.State = StateVerified
+				s.logger.Info("user verified successfully", zap.String("user_id", session.Username))
+				s.events.Publish(session.ID, StateVerified)
 
 				// Send webhook notification
 				if session.CallbackURL != "" {
-					go s.sendWebhook(session, "verified")
+					s.enqueueWebhook(session, "verified")
 				}
 				break
 			}
@@ -330,26 +543,10 @@ func (s *Service) monitorChatMessages() {
 	}
 }
 
-func (s *Service) getActiveSessions() []*Session {
-	s.sessionStore.mutex.RLock()
-	defer s.sessionStore.mutex.RUnlock()
-
-	var activeSessions []*Session
-	for _, session := range s.sessionStore.sessions {
-		if time.Now().Before(session.ExpiresAt) {
-			activeSessions = append(activeSessions, session)
-		}
-	}
-	return activeSessions
-}
-
-func (s *Service) isLocalFriend(userID string) bool {
-	s.friendsMutex.RLock()
-	defer s.friendsMutex.RUnlock()
-	return s.friends[userID]
-}
-
-func (s *Service) sendWebhook(session *Session, status string) {
+// enqueueWebhook persists a pending delivery for session's callback URL. The
+// actual HTTP attempt happens asynchronously in startWebhookDeliveryService,
+// so it's retried with backoff and survives a restart.
+func (s *Service) enqueueWebhook(session *Session, status string) {
 	if session.CallbackURL == "" {
 		return
 	}
@@ -363,68 +560,201 @@ func (s *Service) sendWebhook(session *Session, status string) {
 
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
-		s.logger.Printf("Error marshaling webhook payload for session %s: %v", session.ID, err)
+		s.logger.Error("error marshaling webhook payload",
+			zap.String("session_id", session.ID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	delivery := newWebhookDelivery(session, status, jsonPayload)
+	if err := s.sessionStore.SaveDelivery(delivery); err != nil {
+		s.logger.Error("error enqueuing webhook delivery",
+			zap.String("session_id", session.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+// startWebhookDeliveryService periodically dispatches deliveries that are
+// due for an attempt, bounded by webhookWorkerPoolSize concurrent sends.
+func (s *Service) startWebhookDeliveryService() {
+	s.logger.Info("starting background webhook delivery service")
+
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.isPollerLeader() {
+				continue
+			}
+			s.processPendingDeliveries()
+		}
+	}
+}
+
+func (s *Service) processPendingDeliveries() {
+	pending, err := s.sessionStore.ListPendingDeliveries(time.Now())
+	if err != nil {
+		s.logger.Error("error listing pending webhook deliveries", zap.Error(err))
+		return
+	}
+	if len(pending) == 0 {
 		return
 	}
 
-	req, err := http.NewRequest("POST", session.CallbackURL, bytes.NewBuffer(jsonPayload))
+	sem := make(chan struct{}, webhookWorkerPoolSize)
+	var wg sync.WaitGroup
+	for _, delivery := range pending {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(d *WebhookDelivery) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			s.attemptDelivery(d)
+		}(delivery)
+	}
+	wg.Wait()
+}
+
+// attemptDelivery sends a single webhook attempt, signing the payload with
+// the session's callback secret (falling back to webhookSigningSecret), and
+// schedules a retry with exponential backoff on failure.
+func (s *Service) attemptDelivery(delivery *WebhookDelivery) {
+	secret := s.webhookSigningSecret
+	if session, exists := s.sessionStore.Get(delivery.SessionID); exists && session.CallbackSecret != "" {
+		secret = session.CallbackSecret
+	}
+
+	req, err := http.NewRequest("POST", delivery.URL, bytes.NewReader(delivery.Payload))
 	if err != nil {
-		s.logger.Printf("Error creating webhook request for session %s: %v", session.ID, err)
+		s.logger.Error("error creating webhook request",
+			zap.String("delivery_id", delivery.ID),
+			zap.Error(err),
+		)
+		delivery.Status = DeliveryStatusFailed
+		delivery.LastError = err.Error()
+		_ = s.sessionStore.SaveDelivery(delivery)
 		return
 	}
 
+	// delivery.ID is stable across retries of the same logical event, unlike
+	// a freshly generated UUID, so receivers can dedupe on it.
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "GeoVerification/1.0")
+	req.Header.Set("X-GeoVerify-Signature", signPayload(secret, delivery.Payload))
+	req.Header.Set("X-GeoVerify-Timestamp", strconv.FormatInt(time.Now().Unix(), 10))
+	req.Header.Set("X-GeoVerify-Event-Id", delivery.ID)
+
+	delivery.Attempts++
+	delivery.UpdatedAt = time.Now()
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		s.logger.Printf("Error sending webhook for session %s to %s: %v", session.ID, session.CallbackURL, err)
+		delivery.LastError = err.Error()
+		s.scheduleRetryOrFail(delivery)
+		s.logger.Warn("webhook delivery attempt failed",
+			zap.String("delivery_id", delivery.ID),
+			zap.String("session_id", delivery.SessionID),
+			zap.Int("attempt", delivery.Attempts),
+			zap.Error(err),
+		)
+		_ = s.sessionStore.SaveDelivery(delivery)
 		return
 	}
 	defer resp.Body.Close()
 
+	delivery.LastStatus = resp.StatusCode
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		s.logger.Printf("Webhook sent successfully for session %s (%s)", session.ID, status)
+		delivery.Status = DeliveryStatusDelivered
+		s.logger.Info("webhook delivered",
+			zap.String("delivery_id", delivery.ID),
+			zap.String("session_id", delivery.SessionID),
+			zap.String("event", delivery.Event),
+		)
 	} else {
-		s.logger.Printf("Webhook failed for session %s: HTTP %d", session.ID, resp.StatusCode)
+		delivery.LastError = fmt.Sprintf("http %d", resp.StatusCode)
+		s.scheduleRetryOrFail(delivery)
+		s.logger.Warn("webhook delivery rejected",
+			zap.String("delivery_id", delivery.ID),
+			zap.Int("http_status", resp.StatusCode),
+			zap.Int("attempt", delivery.Attempts),
+		)
+	}
+
+	if err := s.sessionStore.SaveDelivery(delivery); err != nil {
+		s.logger.Error("error saving webhook delivery", zap.String("delivery_id", delivery.ID), zap.Error(err))
 	}
 }
 
+// scheduleRetryOrFail marks delivery permanently failed once it has
+// exhausted webhookMaxAttempts, otherwise schedules the next attempt per
+// webhookBackoffSchedule.
+func (s *Service) scheduleRetryOrFail(delivery *WebhookDelivery) {
+	if delivery.Attempts >= webhookMaxAttempts {
+		delivery.Status = DeliveryStatusFailed
+		s.logger.Warn("webhook delivery exhausted retries",
+			zap.String("delivery_id", delivery.ID),
+			zap.String("session_id", delivery.SessionID),
+		)
+		return
+	}
+	delivery.NextAttempt = time.Now().Add(nextBackoff(delivery.Attempts))
+}
+
+// GetDeliveries returns the webhook delivery history for a session, for
+// GET /api/v1/webhooks/:session_id/deliveries.
+func (s *Service) GetDeliveries(sessionID string) ([]*WebhookDelivery, error) {
+	return s.sessionStore.ListDeliveriesBySession(sessionID)
+}
+
 func (s *Service) startExpirationMonitoringService() {
-	s.logger.Printf("Starting background session expiration monitoring service")
+	s.logger.Info("starting background session expiration monitoring service")
 
 	ticker := time.NewTicker(30 * time.Second) // Check every 30 seconds
 	defer ticker.Stop()
 
-	for range ticker.C {
-		s.monitorExpiredSessions()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.isPollerLeader() {
+				continue
+			}
+			s.monitorExpiredSessions()
+		}
 	}
 }
 
 func (s *Service) monitorExpiredSessions() {
-	s.sessionStore.mutex.RLock()
-	now := time.Now()
-	var expiredSessions []*Session
-
-	for _, session := range s.sessionStore.sessions {
-		if now.After(session.ExpiresAt) && !session.Verified {
-			expiredSessions = append(expiredSessions, session)
-		}
+	expiredSessions, err := s.sessionStore.ListExpiredUnverified()
+	if err != nil {
+		s.logger.Error("error listing expired sessions", zap.Error(err))
+		return
 	}
-	s.sessionStore.mutex.RUnlock()
 
-	// Send webhook notifications for expired sessions and cleanup local friends
+	// Send webhook notifications for expired sessions. SetState records
+	// EXPIRED on the stored session before Publish notifies watchers. A
+	// re-fetch through Get does not return the session once ExpiresAt has
+	// passed, so watchers learn the terminal state from the event itself.
 	for _, session := range expiredSessions {
-		if session.CallbackURL != "" {
-			s.logger.Printf("Sending expiration webhook for session %s", session.ID)
-			go s.sendWebhook(session, "expired")
+		if err := s.sessionStore.SetState(session.ID, StateExpired); err != nil {
+			s.logger.Warn("failed to persist expired state",
+				zap.String("session_id", session.ID),
+				zap.Error(err),
+			)
 		}
+		s.events.Publish(session.ID, StateExpired)
 
-		// Clean up local friend status for expired sessions
-		s.friendsMutex.Lock()
-		delete(s.friends, session.Username)
-		s.friendsMutex.Unlock()
-		s.logger.Printf("Cleaned up local friend status for expired session user %s", session.Username)
+		if session.CallbackURL != "" {
+			s.logger.Info("sending expiration webhook", zap.String("session_id", session.ID))
+			s.enqueueWebhook(session, "expired")
+		}
 	}
 }
 
@@ -445,22 +775,37 @@ func (s *Service) validateCallbackURL(callbackURL string) error {
 	}
 
 	// Check if host is in allowed list
-	if s.allowedHosts[host] {
+	s.configMu.RLock()
+	allowed := s.allowedHosts[host]
+	s.configMu.RUnlock()
+	if allowed {
 		return nil
 	}
 
 	return fmt.Errorf("callback host '%s' not allowed. Configure ALLOWED_CALLBACK_HOSTS environment variable", host)
 }
 
-func (s *Service) checkRateLimit(username string) bool {
+// checkRateLimit enforces a per-key token bucket. It's used both for the
+// per-user limiter (key is the GeoGuessr user ID) and the per-IP limiter
+// (key is ipRateLimitKey(clientIP)), so a single caller can't bypass the
+// per-user limit by enumerating user IDs from one address.
+func (s *Service) checkRateLimit(key string) bool {
 	s.rateMutex.Lock()
 	defer s.rateMutex.Unlock()
 
-	limiter, exists := s.rateLimiters[username]
+	limiter, exists := s.rateLimiters[key]
 	if !exists {
+		s.configMu.RLock()
 		limiter = rate.NewLimiter(s.rateLimitRate, 3)
-		s.rateLimiters[username] = limiter
+		s.configMu.RUnlock()
+		s.rateLimiters[key] = limiter
 	}
 
 	return limiter.Allow()
 }
+
+// ipRateLimitKey namespaces client-IP rate limiter keys so they can't
+// collide with a GeoGuessr user ID of the same value.
+func ipRateLimitKey(clientIP string) string {
+	return "ip:" + clientIP
+}
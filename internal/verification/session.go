@@ -3,91 +3,83 @@ package verification
 import (
 	"crypto/rand"
 	"encoding/hex"
-	"sync"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Session struct {
-	ID          string    `json:"id"`
-	Username    string    `json:"username"`
-	Code        string    `json:"-"`
-	Verified    bool      `json:"verified"`
-	ExpiresAt   time.Time `json:"expires_at"`
-	CreatedAt   time.Time `json:"created_at"`
-	CallbackURL string    `json:"-"` // Don't include in JSON responses
+	ID          string            `json:"id"`
+	Username    string            `json:"username"`
+	Code        string            `json:"-"`
+	Verified    bool              `json:"verified"`
+	State       VerificationState `json:"state"`
+	ExpiresAt   time.Time         `json:"expires_at"`
+	CreatedAt   time.Time         `json:"created_at"`
+	CallbackURL string            `json:"-"` // Don't include in JSON responses
+
+	// CallbackSecret signs webhook deliveries for this session. Set from the
+	// webhook_secret field on StartVerification, falling back to
+	// config.Config.WebhookSigningSecret when empty.
+	CallbackSecret string `json:"-"`
 }
 
-type SessionStore struct {
-	sessions map[string]*Session
-	mutex    sync.RWMutex
+// SessionEvent is fanned out over SessionStore.Events() whenever a session
+// transitions state, so that every replica behind a load balancer can
+// observe state changes made by whichever node actually handled them.
+type SessionEvent struct {
+	SessionID string
+	Username  string
+	Status    string // "pending_friend", "awaiting_code", "verified", or "expired"
 }
 
-func NewSessionStore() *SessionStore {
-	store := &SessionStore{
-		sessions: make(map[string]*Session),
-	}
-
-	go store.cleanupExpired()
-	return store
-}
-
-func (s *SessionStore) Create(username, callbackURL string, expiryDuration time.Duration) (*Session, error) {
-	code, err := generateSecureCode(6)
-	if err != nil {
-		return nil, err
-	}
-
-	session := &Session{
-		ID:          uuid.New().String(),
-		Username:    username,
-		Code:        code,
-		Verified:    false,
-		ExpiresAt:   time.Now().Add(expiryDuration),
-		CreatedAt:   time.Now(),
-		CallbackURL: callbackURL,
-	}
-
-	s.mutex.Lock()
-	s.sessions[session.ID] = session
-	s.mutex.Unlock()
-
-	return session, nil
-}
-
-func (s *SessionStore) Get(sessionID string) (*Session, bool) {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-
-	session, exists := s.sessions[sessionID]
-	if !exists || time.Now().After(session.ExpiresAt) {
-		return nil, false
-	}
-
-	return session, true
-}
-
-func (s *SessionStore) Delete(sessionID string) {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-	delete(s.sessions, sessionID)
-}
-
-func (s *SessionStore) cleanupExpired() {
-	ticker := time.NewTicker(1 * time.Minute)
-	defer ticker.Stop()
-
-	for range ticker.C {
-		s.mutex.Lock()
-		now := time.Now()
-		for id, session := range s.sessions {
-			if now.After(session.ExpiresAt) {
-				delete(s.sessions, id)
-			}
-		}
-		s.mutex.Unlock()
-	}
+// SessionStore abstracts session persistence so the service can run as a
+// single in-memory instance (MemorySessionStore) or as a cluster of
+// replicas sharing state through Redis (RedisSessionStore). Implementations
+// must be safe for concurrent use.
+type SessionStore interface {
+	// Create persists a new session for username and returns it. callbackSecret
+	// may be empty, in which case webhook deliveries fall back to the global
+	// WEBHOOK_SIGNING_SECRET.
+	Create(username, callbackURL, callbackSecret string, expiryDuration time.Duration) (*Session, error)
+	// Get returns the session by ID, or false if it doesn't exist or has expired.
+	Get(sessionID string) (*Session, bool)
+	// Delete removes a session.
+	Delete(sessionID string)
+	// MarkVerified flags a session as verified and publishes a "verified" event.
+	MarkVerified(sessionID string) error
+	// SetState persists a session's state transition (e.g. PENDING_FRIEND ->
+	// AWAITING_CODE) so it survives a re-fetch from the store and is visible
+	// to GetVerificationStatus/WatchVerification without relying on a
+	// caller's in-memory copy.
+	SetState(sessionID string, state VerificationState) error
+	// GetActiveByUsername returns the active (unexpired) session for username, if any.
+	GetActiveByUsername(username string) (*Session, bool)
+	// ListActive returns all unexpired sessions.
+	ListActive() ([]*Session, error)
+	// ListExpiredUnverified returns unverified sessions past their expiry, for
+	// webhook delivery and cleanup.
+	ListExpiredUnverified() ([]*Session, error)
+	// TryAcquirePollerLease attempts to acquire or renew the cluster-wide lease
+	// that gates the background pollers, so only one replica runs them at a
+	// time. holderID should be stable for the lifetime of a process.
+	TryAcquirePollerLease(holderID string, ttl time.Duration) (bool, error)
+	// Events returns a channel of cross-node session state transitions, or nil
+	// if the implementation has no pub/sub transport (e.g. MemorySessionStore).
+	Events() <-chan SessionEvent
+
+	// SaveDelivery upserts a webhook delivery attempt record.
+	SaveDelivery(delivery *WebhookDelivery) error
+	// ListPendingDeliveries returns deliveries that are due for an attempt
+	// (NextAttempt <= now) and haven't reached a terminal state.
+	ListPendingDeliveries(now time.Time) ([]*WebhookDelivery, error)
+	// ListDeliveriesBySession returns the delivery history for a session, for
+	// the GET /webhooks/:session_id/deliveries endpoint. The order of the
+	// returned deliveries is not defined.
+	ListDeliveriesBySession(sessionID string) ([]*WebhookDelivery, error)
+
+	// Close releases any underlying connections.
+	Close() error
 }
 
 func generateSecureCode(length int) (string, error) {
@@ -103,3 +95,7 @@ func generateSecureCode(length int) (string, error) {
 
 	return code, nil
 }
+
+func newSessionID() string {
+	return uuid.New().String()
+}
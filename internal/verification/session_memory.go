@@ -0,0 +1,202 @@
+package verification
+
+import (
+	"sync"
+	"time"
+)
+
+// MemorySessionStore is a single-process SessionStore backed by a
+// mutex-guarded map. It has no pub/sub transport and TryAcquirePollerLease
+// always succeeds, since a single instance is always its own leader.
+type MemorySessionStore struct {
+	sessions map[string]*Session
+	mutex    sync.RWMutex
+
+	deliveries      map[string]*WebhookDelivery
+	deliveriesMutex sync.RWMutex
+}
+
+func NewMemorySessionStore() *MemorySessionStore {
+	store := &MemorySessionStore{
+		sessions:   make(map[string]*Session),
+		deliveries: make(map[string]*WebhookDelivery),
+	}
+
+	go store.cleanupExpired()
+	return store
+}
+
+func (s *MemorySessionStore) Create(username, callbackURL, callbackSecret string, expiryDuration time.Duration) (*Session, error) {
+	code, err := generateSecureCode(6)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &Session{
+		ID:             newSessionID(),
+		Username:       username,
+		Code:           code,
+		Verified:       false,
+		State:          StatePendingFriend,
+		ExpiresAt:      time.Now().Add(expiryDuration),
+		CreatedAt:      time.Now(),
+		CallbackURL:    callbackURL,
+		CallbackSecret: callbackSecret,
+	}
+
+	s.mutex.Lock()
+	s.sessions[session.ID] = session
+	s.mutex.Unlock()
+
+	return session, nil
+}
+
+func (s *MemorySessionStore) Get(sessionID string) (*Session, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	session, exists := s.sessions[sessionID]
+	if !exists || time.Now().After(session.ExpiresAt) {
+		return nil, false
+	}
+
+	return session, true
+}
+
+func (s *MemorySessionStore) Delete(sessionID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.sessions, sessionID)
+}
+
+func (s *MemorySessionStore) MarkVerified(sessionID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	session, exists := s.sessions[sessionID]
+	if !exists {
+		return nil
+	}
+	session.Verified = true
+	session.State = StateVerified
+	return nil
+}
+
+// SetState persists a state transition directly on the stored session
+// pointer; since MemorySessionStore's Get returns that same pointer, the
+// change is visible immediately without a separate save step.
+func (s *MemorySessionStore) SetState(sessionID string, state VerificationState) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	session, exists := s.sessions[sessionID]
+	if !exists {
+		return nil
+	}
+	session.State = state
+	return nil
+}
+
+func (s *MemorySessionStore) GetActiveByUsername(username string) (*Session, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, session := range s.sessions {
+		if session.Username == username && time.Now().Before(session.ExpiresAt) {
+			return session, true
+		}
+	}
+	return nil, false
+}
+
+func (s *MemorySessionStore) ListActive() ([]*Session, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var active []*Session
+	now := time.Now()
+	for _, session := range s.sessions {
+		if now.Before(session.ExpiresAt) {
+			active = append(active, session)
+		}
+	}
+	return active, nil
+}
+
+func (s *MemorySessionStore) ListExpiredUnverified() ([]*Session, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var expired []*Session
+	now := time.Now()
+	for _, session := range s.sessions {
+		if now.After(session.ExpiresAt) && !session.Verified {
+			expired = append(expired, session)
+		}
+	}
+	return expired, nil
+}
+
+// TryAcquirePollerLease always succeeds: a single in-memory instance never
+// has peers to contend with.
+func (s *MemorySessionStore) TryAcquirePollerLease(holderID string, ttl time.Duration) (bool, error) {
+	return true, nil
+}
+
+// Events returns nil: there are no other replicas to hear from.
+func (s *MemorySessionStore) Events() <-chan SessionEvent {
+	return nil
+}
+
+func (s *MemorySessionStore) SaveDelivery(delivery *WebhookDelivery) error {
+	s.deliveriesMutex.Lock()
+	defer s.deliveriesMutex.Unlock()
+	s.deliveries[delivery.ID] = delivery
+	return nil
+}
+
+func (s *MemorySessionStore) ListPendingDeliveries(now time.Time) ([]*WebhookDelivery, error) {
+	s.deliveriesMutex.RLock()
+	defer s.deliveriesMutex.RUnlock()
+
+	var pending []*WebhookDelivery
+	for _, delivery := range s.deliveries {
+		if delivery.Status == DeliveryStatusPending && !delivery.NextAttempt.After(now) {
+			pending = append(pending, delivery)
+		}
+	}
+	return pending, nil
+}
+
+func (s *MemorySessionStore) ListDeliveriesBySession(sessionID string) ([]*WebhookDelivery, error) {
+	s.deliveriesMutex.RLock()
+	defer s.deliveriesMutex.RUnlock()
+
+	var deliveries []*WebhookDelivery
+	for _, delivery := range s.deliveries {
+		if delivery.SessionID == sessionID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+func (s *MemorySessionStore) Close() error {
+	return nil
+}
+
+func (s *MemorySessionStore) cleanupExpired() {
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.mutex.Lock()
+		now := time.Now()
+		for id, session := range s.sessions {
+			if now.After(session.ExpiresAt) {
+				delete(s.sessions, id)
+			}
+		}
+		s.mutex.Unlock()
+	}
+}
@@ -0,0 +1,368 @@
+package verification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	redisEventsChannel    = "geo-verification:events"
+	redisPollerLockKey    = "poller:lock"
+	redisUserIndexKey     = "user:%s"
+	redisSessionKey       = "session:%s"
+	redisDeliveryKey      = "delivery:%s"
+	redisDeliveryIndexKey = "deliveries:%s" // deliveries:<session_id> -> set of delivery IDs
+	redisDeliveryTTL      = 7 * 24 * time.Hour
+)
+
+// RedisSessionStore is a SessionStore backed by Redis, making the service
+// safe to run as multiple replicas behind a load balancer: a session
+// created on one node is immediately visible to the others via the shared
+// keyspace, and terminal-state transitions are fanned out over pub/sub so
+// every node's view stays current.
+type RedisSessionStore struct {
+	client  *redis.Client
+	logger  *zap.Logger
+	events  chan SessionEvent
+	closeCh chan struct{}
+}
+
+func NewRedisSessionStore(redisURL string, logger *zap.Logger) (*RedisSessionStore, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid REDIS_URL: %w", err)
+	}
+
+	client := redis.NewClient(opts)
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	store := &RedisSessionStore{
+		client:  client,
+		logger:  logger,
+		events:  make(chan SessionEvent, 32),
+		closeCh: make(chan struct{}),
+	}
+
+	go store.listen()
+	return store, nil
+}
+
+func (s *RedisSessionStore) listen() {
+	sub := s.client.Subscribe(context.Background(), redisEventsChannel)
+	defer sub.Close()
+
+	ch := sub.Channel()
+	for {
+		select {
+		case <-s.closeCh:
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			var event SessionEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				s.logger.Warn("failed to decode session event", zap.Error(err))
+				continue
+			}
+			select {
+			case s.events <- event:
+			default:
+				s.logger.Warn("session event channel full, dropping event", zap.String("session_id", event.SessionID))
+			}
+		}
+	}
+}
+
+func (s *RedisSessionStore) publish(event SessionEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		s.logger.Error("failed to marshal session event", zap.Error(err))
+		return
+	}
+	if err := s.client.Publish(context.Background(), redisEventsChannel, payload).Err(); err != nil {
+		s.logger.Error("failed to publish session event", zap.Error(err))
+	}
+}
+
+func (s *RedisSessionStore) Create(username, callbackURL, callbackSecret string, expiryDuration time.Duration) (*Session, error) {
+	code, err := generateSecureCode(6)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &Session{
+		ID:             newSessionID(),
+		Username:       username,
+		Code:           code,
+		Verified:       false,
+		State:          StatePendingFriend,
+		ExpiresAt:      time.Now().Add(expiryDuration),
+		CreatedAt:      time.Now(),
+		CallbackURL:    callbackURL,
+		CallbackSecret: callbackSecret,
+	}
+
+	if err := s.save(session, expiryDuration); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (s *RedisSessionStore) save(session *Session, ttl time.Duration) error {
+	ctx := context.Background()
+	payload, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, fmt.Sprintf(redisSessionKey, session.ID), payload, ttl)
+	pipe.Set(ctx, fmt.Sprintf(redisUserIndexKey, session.Username), session.ID, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to persist session: %w", err)
+	}
+	return nil
+}
+
+func (s *RedisSessionStore) Get(sessionID string) (*Session, bool) {
+	ctx := context.Background()
+	payload, err := s.client.Get(ctx, fmt.Sprintf(redisSessionKey, sessionID)).Bytes()
+	if err != nil {
+		return nil, false
+	}
+
+	var session Session
+	if err := json.Unmarshal(payload, &session); err != nil {
+		s.logger.Error("failed to decode session", zap.String("session_id", sessionID), zap.Error(err))
+		return nil, false
+	}
+
+	return &session, true
+}
+
+func (s *RedisSessionStore) Delete(sessionID string) {
+	session, ok := s.Get(sessionID)
+	ctx := context.Background()
+	s.client.Del(ctx, fmt.Sprintf(redisSessionKey, sessionID))
+	if ok {
+		s.client.Del(ctx, fmt.Sprintf(redisUserIndexKey, session.Username))
+	}
+}
+
+func (s *RedisSessionStore) MarkVerified(sessionID string) error {
+	session, ok := s.Get(sessionID)
+	if !ok {
+		return nil
+	}
+
+	session.Verified = true
+	session.State = StateVerified
+	ttl := time.Until(session.ExpiresAt)
+	if ttl <= 0 {
+		ttl = time.Second
+	}
+	if err := s.save(session, ttl); err != nil {
+		return err
+	}
+
+	s.publish(SessionEvent{SessionID: session.ID, Username: session.Username, Status: "verified"})
+	return nil
+}
+
+// SetState persists a session's state transition and publishes it over
+// pub/sub, so a replica other than the one that made the transition (e.g.
+// one holding a gRPC WatchVerification stream for this session) hears about
+// it via consumeRemoteEvents instead of hanging until the session's TTL
+// silently ends the stream.
+func (s *RedisSessionStore) SetState(sessionID string, state VerificationState) error {
+	session, ok := s.Get(sessionID)
+	if !ok {
+		return nil
+	}
+
+	session.State = state
+	ttl := time.Until(session.ExpiresAt)
+	if ttl <= 0 {
+		ttl = time.Second
+	}
+	if err := s.save(session, ttl); err != nil {
+		return err
+	}
+
+	s.publish(SessionEvent{SessionID: session.ID, Username: session.Username, Status: stateEventStatus(state)})
+	return nil
+}
+
+// stateEventStatus maps a VerificationState to the SessionEvent.Status
+// string published over Redis pub/sub and consumed by consumeRemoteEvents.
+func stateEventStatus(state VerificationState) string {
+	switch state {
+	case StatePendingFriend:
+		return "pending_friend"
+	case StateAwaitingCode:
+		return "awaiting_code"
+	case StateVerified:
+		return "verified"
+	case StateExpired:
+		return "expired"
+	default:
+		return string(state)
+	}
+}
+
+func (s *RedisSessionStore) GetActiveByUsername(username string) (*Session, bool) {
+	ctx := context.Background()
+	sessionID, err := s.client.Get(ctx, fmt.Sprintf(redisUserIndexKey, username)).Result()
+	if err != nil {
+		return nil, false
+	}
+	return s.Get(sessionID)
+}
+
+func (s *RedisSessionStore) ListActive() ([]*Session, error) {
+	return s.scan(func(session *Session) bool {
+		return time.Now().Before(session.ExpiresAt)
+	})
+}
+
+func (s *RedisSessionStore) ListExpiredUnverified() ([]*Session, error) {
+	return s.scan(func(session *Session) bool {
+		return time.Now().After(session.ExpiresAt) && !session.Verified
+	})
+}
+
+func (s *RedisSessionStore) scan(match func(*Session) bool) ([]*Session, error) {
+	ctx := context.Background()
+	var matched []*Session
+
+	iter := s.client.Scan(ctx, 0, "session:*", 100).Iterator()
+	for iter.Next(ctx) {
+		payload, err := s.client.Get(ctx, iter.Val()).Bytes()
+		if err != nil {
+			continue
+		}
+		var session Session
+		if err := json.Unmarshal(payload, &session); err != nil {
+			continue
+		}
+		if match(&session) {
+			matched = append(matched, &session)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan sessions: %w", err)
+	}
+
+	return matched, nil
+}
+
+// TryAcquirePollerLease uses SET NX PX to elect a single leader across
+// replicas, and extends the lease on each successful renewal by the same
+// holder so the pollers keep running on whichever node already owns them.
+func (s *RedisSessionStore) TryAcquirePollerLease(holderID string, ttl time.Duration) (bool, error) {
+	ctx := context.Background()
+
+	acquired, err := s.client.SetNX(ctx, redisPollerLockKey, holderID, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire poller lease: %w", err)
+	}
+	if acquired {
+		return true, nil
+	}
+
+	current, err := s.client.Get(ctx, redisPollerLockKey).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to read poller lease holder: %w", err)
+	}
+	if current != holderID {
+		return false, nil
+	}
+
+	if err := s.client.PExpire(ctx, redisPollerLockKey, ttl).Err(); err != nil {
+		return false, fmt.Errorf("failed to renew poller lease: %w", err)
+	}
+	return true, nil
+}
+
+func (s *RedisSessionStore) Events() <-chan SessionEvent {
+	return s.events
+}
+
+func (s *RedisSessionStore) SaveDelivery(delivery *WebhookDelivery) error {
+	ctx := context.Background()
+	payload, err := json.Marshal(delivery)
+	if err != nil {
+		return fmt.Errorf("failed to marshal delivery: %w", err)
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, fmt.Sprintf(redisDeliveryKey, delivery.ID), payload, redisDeliveryTTL)
+	pipe.SAdd(ctx, fmt.Sprintf(redisDeliveryIndexKey, delivery.SessionID), delivery.ID)
+	pipe.Expire(ctx, fmt.Sprintf(redisDeliveryIndexKey, delivery.SessionID), redisDeliveryTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to persist delivery: %w", err)
+	}
+	return nil
+}
+
+func (s *RedisSessionStore) ListPendingDeliveries(now time.Time) ([]*WebhookDelivery, error) {
+	ctx := context.Background()
+	var pending []*WebhookDelivery
+
+	iter := s.client.Scan(ctx, 0, "delivery:*", 100).Iterator()
+	for iter.Next(ctx) {
+		payload, err := s.client.Get(ctx, iter.Val()).Bytes()
+		if err != nil {
+			continue
+		}
+		var delivery WebhookDelivery
+		if err := json.Unmarshal(payload, &delivery); err != nil {
+			continue
+		}
+		if delivery.Status == DeliveryStatusPending && !delivery.NextAttempt.After(now) {
+			pending = append(pending, &delivery)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan deliveries: %w", err)
+	}
+
+	return pending, nil
+}
+
+func (s *RedisSessionStore) ListDeliveriesBySession(sessionID string) ([]*WebhookDelivery, error) {
+	ctx := context.Background()
+	ids, err := s.client.SMembers(ctx, fmt.Sprintf(redisDeliveryIndexKey, sessionID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deliveries for session: %w", err)
+	}
+
+	var deliveries []*WebhookDelivery
+	for _, id := range ids {
+		payload, err := s.client.Get(ctx, fmt.Sprintf(redisDeliveryKey, id)).Bytes()
+		if err != nil {
+			continue
+		}
+		var delivery WebhookDelivery
+		if err := json.Unmarshal(payload, &delivery); err != nil {
+			continue
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+
+	return deliveries, nil
+}
+
+func (s *RedisSessionStore) Close() error {
+	close(s.closeCh)
+	return s.client.Close()
+}
@@ -0,0 +1,86 @@
+package verification
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// webhookBackoffSchedule gives the delay before each retry attempt after the
+// first (immediate) delivery: 5s, 30s, 2m, 10m, 30m. Combined with the
+// initial attempt that's up to webhookMaxAttempts tries total.
+var webhookBackoffSchedule = []time.Duration{
+	5 * time.Second,
+	30 * time.Second,
+	2 * time.Minute,
+	10 * time.Minute,
+	30 * time.Minute,
+}
+
+// webhookMaxAttempts is the initial delivery plus one retry per entry in
+// webhookBackoffSchedule. Kept as a literal rather than 1 + len(...) since
+// len() of a package-level var isn't a Go constant expression.
+const webhookMaxAttempts = 6
+
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusFailed    = "failed"
+)
+
+// WebhookDelivery records a single callback notification and its retry
+// history, so deliveries survive a restart and are visible via
+// GET /api/v1/webhooks/:session_id/deliveries.
+type WebhookDelivery struct {
+	ID          string    `json:"id"`
+	SessionID   string    `json:"session_id"`
+	URL         string    `json:"url"`
+	Status      string    `json:"status"` // pending, delivered, failed
+	Event       string    `json:"event"`  // "verified" or "expired"
+	Payload     []byte    `json:"payload"`
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"next_attempt"`
+	LastStatus  int       `json:"last_status,omitempty"`
+	LastError   string    `json:"last_error,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func newWebhookDelivery(session *Session, event string, payload []byte) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:          uuid.New().String(),
+		SessionID:   session.ID,
+		URL:         session.CallbackURL,
+		Status:      DeliveryStatusPending,
+		Event:       event,
+		Payload:     payload,
+		Attempts:    0,
+		NextAttempt: now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// nextBackoff returns the delay before the attempt after attemptsSoFar.
+func nextBackoff(attemptsSoFar int) time.Duration {
+	idx := attemptsSoFar - 1
+	if idx < 0 {
+		return 0
+	}
+	if idx >= len(webhookBackoffSchedule) {
+		idx = len(webhookBackoffSchedule) - 1
+	}
+	return webhookBackoffSchedule[idx]
+}
+
+// signPayload computes the hex-encoded HMAC-SHA256 over payload using secret,
+// emitted as the X-GeoVerify-Signature header value (prefixed "sha256=").
+func signPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -1,34 +1,64 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/lonanche/geo-verification/config"
 	"github.com/lonanche/geo-verification/internal/api"
 	"github.com/lonanche/geo-verification/internal/geoguessr"
+	"github.com/lonanche/geo-verification/internal/grpcapi"
 	"github.com/lonanche/geo-verification/internal/logger"
 	"github.com/lonanche/geo-verification/internal/verification"
+	"github.com/lonanche/geo-verification/proto/verificationpb"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
 )
 
+// shutdownTimeout bounds how long graceful shutdown waits for in-flight
+// requests, background pollers, and the webhook retry queue to drain.
+const shutdownTimeout = 15 * time.Second
+
 func main() {
-	appLogger := logger.New("geo-verification")
 	cfg := config.Load()
 
+	appLogger, logLevel, err := logger.New("geo-verification", cfg.LogLevel, cfg.LogFormat)
+	if err != nil {
+		log.Fatalf("[geo-verification] failed to initialize logger: %v", err)
+	}
+	defer appLogger.Sync()
+
 	if cfg.GeoGuessrNcfaToken == "" {
-		log.Fatal("[geo-verification] GEOGUESSR_NCFA_TOKEN environment variable must be set")
+		appLogger.Fatal("GEOGUESSR_NCFA_TOKEN environment variable must be set")
 	}
 
-	geoClient := geoguessr.NewClient(cfg.GeoGuessrNcfaToken, appLogger)
+	geoClient := geoguessr.NewClient(cfg.GeoGuessrNcfaToken, appLogger.Sugar())
 
 	if err := geoClient.Login(); err != nil {
-		appLogger.Fatalf("Failed to login to GeoGuessr: %v", err)
+		appLogger.Fatal("failed to login to GeoGuessr", zap.Error(err))
+	}
+
+	sessionStore, err := newSessionStore(cfg, appLogger)
+	if err != nil {
+		appLogger.Fatal("failed to initialize session store", zap.Error(err))
 	}
 
 	verificationService := verification.NewService(
 		geoClient,
+		sessionStore,
 		cfg.RateLimitPerHour,
 		cfg.CodeExpiryDuration(),
+		cfg.AllowedCallbackHosts,
+		cfg.WebhookSigningSecret,
 		appLogger,
 	)
 
@@ -36,21 +66,144 @@ func main() {
 
 	router := gin.New()
 
-	api.SetupMiddleware(router)
+	api.SetupMiddleware(router, api.ParseTrustedProxies(cfg.TrustedProxies))
 
 	v1 := router.Group("/api/v1")
 	{
 		v1.POST("/verify/start", handler.StartVerification)
 		v1.GET("/verify/status/:session_id", handler.GetVerificationStatus)
+		v1.GET("/webhooks/:session_id/deliveries", handler.GetWebhookDeliveries)
 	}
 
 	router.GET("/health", handler.HealthCheck)
 
-	appLogger.Printf("Starting GeoGuessr verification service on port %s", cfg.Port)
-	appLogger.Printf("Rate limit: %d requests per hour per user", cfg.RateLimitPerHour)
-	appLogger.Printf("Code expiry: %d minutes", cfg.CodeExpiryMinutes)
+	httpServer := &http.Server{
+		Addr:    ":" + cfg.Port,
+		Handler: router,
+	}
+
+	appLogger.Info("starting GeoGuessr verification service",
+		zap.String("port", cfg.Port),
+		zap.Int("rate_limit_per_hour", cfg.RateLimitPerHour),
+		zap.Int("code_expiry_minutes", cfg.CodeExpiryMinutes),
+	)
 
-	if err := router.Run(":" + cfg.Port); err != nil {
-		appLogger.Fatalf("Failed to start server: %v", err)
+	serverErrors := make(chan error, 1)
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErrors <- err
+		}
+	}()
+
+	grpcServer, err := startGRPCServer(cfg, verificationService, appLogger, serverErrors)
+	if err != nil {
+		appLogger.Fatal("failed to start gRPC server", zap.Error(err))
 	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+
+	for {
+		select {
+		case err := <-serverErrors:
+			appLogger.Fatal("server failed to start", zap.Error(err))
+
+		case sig := <-signals:
+			if sig == syscall.SIGHUP {
+				reloadConfig(appLogger, logLevel, verificationService)
+				continue
+			}
+
+			appLogger.Info("received shutdown signal, draining in-flight work", zap.String("signal", sig.String()))
+
+			ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			if err := httpServer.Shutdown(ctx); err != nil {
+				appLogger.Error("error shutting down HTTP server", zap.Error(err))
+			}
+			if grpcServer != nil {
+				stopped := make(chan struct{})
+				go func() {
+					grpcServer.GracefulStop()
+					close(stopped)
+				}()
+				select {
+				case <-stopped:
+				case <-ctx.Done():
+					appLogger.Warn("gRPC graceful stop did not finish in time, forcing shutdown")
+					grpcServer.Stop()
+					<-stopped
+				}
+			}
+			if err := verificationService.Close(ctx); err != nil {
+				appLogger.Error("error shutting down verification service", zap.Error(err))
+			}
+			cancel()
+
+			appLogger.Info("shutdown complete")
+			return
+		}
+	}
+}
+
+// reloadConfig re-reads the environment and applies the mutable subset of
+// config.Config (rate limits, code expiry, allowed callback hosts, log
+// level) without restarting the process.
+func reloadConfig(appLogger *zap.Logger, logLevel zap.AtomicLevel, verificationService *verification.Service) {
+	cfg := config.Load()
+
+	if newLevel, err := zapcore.ParseLevel(cfg.LogLevel); err != nil {
+		appLogger.Warn("SIGHUP reload: invalid LOG_LEVEL, keeping current level", zap.String("log_level", cfg.LogLevel))
+	} else {
+		logLevel.SetLevel(newLevel)
+	}
+
+	verificationService.Reconfigure(cfg.RateLimitPerHour, cfg.CodeExpiryDuration(), cfg.AllowedCallbackHosts)
+
+	appLogger.Info("reloaded configuration on SIGHUP",
+		zap.String("log_level", cfg.LogLevel),
+		zap.Int("rate_limit_per_hour", cfg.RateLimitPerHour),
+		zap.Int("code_expiry_minutes", cfg.CodeExpiryMinutes),
+		zap.String("allowed_callback_hosts", cfg.AllowedCallbackHosts),
+	)
+}
+
+// startGRPCServer starts the gRPC transport on GRPC_PORT, sharing
+// verificationService with the REST API. It is disabled when GRPC_PORT is
+// unset, which is the default, since most deployments only need the REST
+// API and webhooks. Errors encountered after the listener starts serving
+// are reported on serverErrors, mirroring the HTTP server above.
+func startGRPCServer(cfg *config.Config, verificationService *verification.Service, appLogger *zap.Logger, serverErrors chan<- error) (*grpc.Server, error) {
+	if cfg.GRPCPort == "" {
+		return nil, nil
+	}
+
+	listener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		return nil, err
+	}
+
+	grpcServer := grpc.NewServer()
+	verificationpb.RegisterGeoVerificationServer(grpcServer, grpcapi.NewServer(verificationService, appLogger))
+
+	appLogger.Info("starting gRPC server", zap.String("port", cfg.GRPCPort))
+
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			serverErrors <- err
+		}
+	}()
+
+	return grpcServer, nil
+}
+
+// newSessionStore selects the session store backend: Redis when REDIS_URL is
+// configured, so the service can run as multiple replicas behind a load
+// balancer, or an in-memory store for single-instance deployments.
+func newSessionStore(cfg *config.Config, appLogger *zap.Logger) (verification.SessionStore, error) {
+	if cfg.RedisURL == "" {
+		return verification.NewMemorySessionStore(), nil
+	}
+
+	appLogger.Info("using redis-backed session store", zap.String("redis_url", cfg.RedisURL))
+	return verification.NewRedisSessionStore(cfg.RedisURL, appLogger)
 }
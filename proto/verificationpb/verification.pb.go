@@ -0,0 +1,184 @@
+// Hand-maintained stand-in for protoc-gen-go output: this sandbox has no
+// network access to install protoc/protoc-gen-go, so `make proto` can't run
+// for real here. Keep this in sync with proto/verification.proto by hand —
+// in particular, every field needs a Get<Field>() accessor, since callers
+// (internal/grpcapi) use those rather than direct field access, matching
+// real generated code.
+package verificationpb
+
+import "fmt"
+
+type VerificationState int32
+
+const (
+	VerificationState_VERIFICATION_STATE_UNSPECIFIED VerificationState = 0
+	VerificationState_PENDING_FRIEND                 VerificationState = 1
+	VerificationState_AWAITING_CODE                   VerificationState = 2
+	VerificationState_VERIFIED                        VerificationState = 3
+	VerificationState_EXPIRED                         VerificationState = 4
+)
+
+var VerificationState_name = map[int32]string{
+	0: "VERIFICATION_STATE_UNSPECIFIED",
+	1: "PENDING_FRIEND",
+	2: "AWAITING_CODE",
+	3: "VERIFIED",
+	4: "EXPIRED",
+}
+
+func (s VerificationState) String() string {
+	if name, ok := VerificationState_name[int32(s)]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+type StartVerificationRequest struct {
+	UserId        string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CallbackUrl   string `protobuf:"bytes,2,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`
+	WebhookSecret string `protobuf:"bytes,3,opt,name=webhook_secret,json=webhookSecret,proto3" json:"webhook_secret,omitempty"`
+}
+
+// Reset, String, and ProtoMessage satisfy the legacy proto.Message
+// interface, which is all the grpc-go wire codec needs to marshal a
+// protoc-gen-go-shaped struct (see the note atop this file).
+func (m *StartVerificationRequest) Reset()         { *m = StartVerificationRequest{} }
+func (m *StartVerificationRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*StartVerificationRequest) ProtoMessage()    {}
+
+func (m *StartVerificationRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *StartVerificationRequest) GetCallbackUrl() string {
+	if m != nil {
+		return m.CallbackUrl
+	}
+	return ""
+}
+
+func (m *StartVerificationRequest) GetWebhookSecret() string {
+	if m != nil {
+		return m.WebhookSecret
+	}
+	return ""
+}
+
+type StartVerificationResponse struct {
+	SessionId        string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	VerificationCode string `protobuf:"bytes,2,opt,name=verification_code,json=verificationCode,proto3" json:"verification_code,omitempty"`
+	ExpiresAt        string `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Message          string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (m *StartVerificationResponse) Reset()         { *m = StartVerificationResponse{} }
+func (m *StartVerificationResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*StartVerificationResponse) ProtoMessage()    {}
+
+func (m *StartVerificationResponse) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *StartVerificationResponse) GetVerificationCode() string {
+	if m != nil {
+		return m.VerificationCode
+	}
+	return ""
+}
+
+func (m *StartVerificationResponse) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+func (m *StartVerificationResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type GetVerificationStatusRequest struct {
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (m *GetVerificationStatusRequest) Reset()         { *m = GetVerificationStatusRequest{} }
+func (m *GetVerificationStatusRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*GetVerificationStatusRequest) ProtoMessage()    {}
+
+func (m *GetVerificationStatusRequest) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+type WatchVerificationRequest struct {
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (m *WatchVerificationRequest) Reset()         { *m = WatchVerificationRequest{} }
+func (m *WatchVerificationRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*WatchVerificationRequest) ProtoMessage()    {}
+
+func (m *WatchVerificationRequest) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+type VerificationStatus struct {
+	SessionId string            `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Username  string            `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	State     VerificationState `protobuf:"varint,3,opt,name=state,proto3,enum=geoverification.v1.VerificationState" json:"state,omitempty"`
+	ExpiresAt string            `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt string            `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (m *VerificationStatus) Reset()         { *m = VerificationStatus{} }
+func (m *VerificationStatus) String() string { return fmt.Sprintf("%+v", *m) }
+func (*VerificationStatus) ProtoMessage()    {}
+
+func (m *VerificationStatus) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *VerificationStatus) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *VerificationStatus) GetState() VerificationState {
+	if m != nil {
+		return m.State
+	}
+	return VerificationState_VERIFICATION_STATE_UNSPECIFIED
+}
+
+func (m *VerificationStatus) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+func (m *VerificationStatus) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}